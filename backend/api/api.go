@@ -0,0 +1,11 @@
+// Package api встраивает в бинарник контракты, которые backend отдает
+// клиентам.
+package api
+
+import _ "embed"
+
+// OpenAPI — описание REST API из routes.InitRoutes. Входящие запросы
+// проверяются по нему же, поэтому документ и поведение не расходятся.
+//
+//go:embed openapi.json
+var OpenAPI []byte
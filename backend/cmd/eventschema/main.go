@@ -0,0 +1,23 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+)
+
+func main() {
+	output := flag.String("o", "api/events.schema.json", "path to write the JSON Schema to")
+	flag.Parse()
+
+	schema, err := events.JSONSchema()
+	if err != nil {
+		log.Fatalf("Failed to build event schema: %v", err)
+	}
+
+	if err := os.WriteFile(*output, append(schema, '\n'), 0o644); err != nil {
+		log.Fatalf("Failed to write event schema: %v", err)
+	}
+}
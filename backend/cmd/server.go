@@ -7,6 +7,7 @@ import (
 	fiberLog "github.com/gofiber/fiber/v2/log"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/websocket/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/internal/routes"
@@ -16,7 +17,7 @@ import (
 )
 
 func main() {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	cfg := config.MustLoad()
 	db, err := repository.InitDataBase(cfg.Database)
 
@@ -24,12 +25,19 @@ func main() {
 		fiberLog.Error("Error with connecting to db", sl.Err(err))
 	}
 
-	wsHub := ws.NewHub()
+	droneRepo := &repository.DroneRepository{DB: db}
+	pilotRepo := &repository.PilotRepository{DB: db}
+	applicationRepo := &repository.ApplicationRepository{DB: db}
+	zonesRepo := &repository.ZonesRepository{DB: db}
+	notificationRepo := &repository.NotificationRepository{DB: db}
+
+	wsHub := ws.NewHub(applicationRepo, notificationRepo)
 	go wsHub.Run()
+	go server.RunNotificationCleanup(notificationRepo, cfg.Retention)
 
 	go func() {
-		log.Println("Starting gRPC server on port 1234...")
-		if err := server.StartGRPCServer(wsHub, "1234"); err != nil {
+		log.Printf("Starting gRPC server on port %s...", cfg.GRPC.Port)
+		if err := server.StartGRPCServer(cfg, wsHub, notificationRepo, *pilotRepo, *droneRepo, *applicationRepo, *zonesRepo); err != nil {
 			log.Fatalf("Failed to start gRPC server: %v", err)
 		}
 	}()
@@ -37,24 +45,22 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000",
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, Last-Event-ID",
 		AllowCredentials: true,
 	}))
 
-	droneRepo := &repository.DroneRepository{DB: db}
-	pilotRepo := &repository.PilotRepository{DB: db}
-	applicationRepo := &repository.ApplicationRepository{DB: db}
-	zonesRepo := &repository.ZonesRepository{DB: db}
-
-	app.Use("/ws", ws.WebSocketUpgrade)
+	app.Use("/ws", ws.WebSocketUpgrade(cfg.JWTSecretKey))
 	app.Get("/ws", websocket.New(wsHub.HandleWebSocket))
+	app.Get("/events/schema.json", ws.EventSchema)
+	app.Get("/events", ws.StreamAuth(cfg.JWTSecretKey), wsHub.HandleSSE)
 
 	routes.InitRoutes(app, cfg, *pilotRepo, *droneRepo, *applicationRepo, *zonesRepo)
 
 	log.Println("Server starting...")
 	log.Printf("HTTP API on %s", cfg.Port)
-	log.Println("gRPC server on :1234")
+	log.Printf("gRPC server on :%s", cfg.GRPC.Port)
 	log.Println("WebSocket endpoint: /ws")
+	log.Println("SSE endpoint: /events")
 
 	err = app.Listen(cfg.Port)
 	if err != nil {
@@ -0,0 +1,125 @@
+// Package apperror — единая модель ошибок HTTP API. Обработчики возвращают
+// *Error, а Handler превращает ее в статус и общий JSON-конверт:
+//
+//	{"error": {"code": "not_found", "message": "Drone not found"}}
+package apperror
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Code string
+
+const (
+	CodeValidation   Code = "validation"
+	CodeNotFound     Code = "not_found"
+	CodeConflict     Code = "conflict"
+	CodeUnauthorized Code = "unauthorized"
+	CodeForbidden    Code = "forbidden"
+	CodeInternal     Code = "internal"
+)
+
+var statuses = map[Code]int{
+	CodeValidation:   fiber.StatusBadRequest,
+	CodeNotFound:     fiber.StatusNotFound,
+	CodeConflict:     fiber.StatusConflict,
+	CodeUnauthorized: fiber.StatusUnauthorized,
+	CodeForbidden:    fiber.StatusForbidden,
+	CodeInternal:     fiber.StatusInternalServerError,
+}
+
+// FieldError описывает нарушение в одном поле запроса. Field — путь к полю
+// с префиксом источника: body.latitude, path.id, query.max_points.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error — ошибка, которую видит клиент. Err — исходная причина, она
+// попадает только в лог.
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+
+	status int
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Status() int {
+	if e.status != 0 {
+		return e.status
+	}
+
+	if status, ok := statuses[e.Code]; ok {
+		return status
+	}
+
+	return fiber.StatusInternalServerError
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, message)
+}
+
+func Internal(message string, err error) *Error {
+	return &Error{Code: CodeInternal, Message: message, Err: err}
+}
+
+// From приводит любую ошибку к *Error. Ошибки Fiber (неизвестный маршрут,
+// слишком большое тело) сохраняют свой статус, все остальное — internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		for code, status := range statuses {
+			if status == fiberErr.Code && code != CodeInternal {
+				return &Error{Code: code, Message: fiberErr.Message, Err: err}
+			}
+		}
+
+		if fiberErr.Code < fiber.StatusInternalServerError {
+			return &Error{Code: CodeValidation, Message: fiberErr.Message, Err: err, status: fiberErr.Code}
+		}
+	}
+
+	return Internal("Internal server error", err)
+}
@@ -0,0 +1,27 @@
+package apperror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type body struct {
+	Code    Code         `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// Handler — fiber.Config.ErrorHandler для всего HTTP API.
+func Handler(c *fiber.Ctx, err error) error {
+	appErr := From(err)
+
+	if appErr.Code == CodeInternal {
+		log.Errorf("%s %s: %v", c.Method(), c.Path(), err)
+	}
+
+	return c.Status(appErr.Status()).JSON(fiber.Map{"error": body{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+		Fields:  appErr.Fields,
+	}})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -12,12 +13,38 @@ type Config struct {
 	JWTSecretKey string `yaml:"jwtsecretkey"`
 	Server       `yaml:"server"`
 	Database     `yaml:"database"`
+	GRPC         GRPC      `yaml:"grpc"`
+	Retention    Retention `yaml:"retention"`
 }
 
 type Server struct {
 	Port string `yaml:"port" env-default:":5050"`
 }
 
+// GRPC настраивает сервер, принимающий уведомления от процессора полетов.
+// Без Insecure сервер не стартует без сертификата. AuthTokens сопоставляет
+// токен с именем сервиса; вызовы без известного токена отклоняются.
+// Permissions перечисляет gRPC-сервисы, доступные каждому из них.
+type GRPC struct {
+	Port         string              `yaml:"port" env:"GRPC_PORT" env-default:"1234"`
+	Insecure     bool                `yaml:"insecure" env:"GRPC_INSECURE"`
+	CertFile     string              `yaml:"cert_file" env:"GRPC_CERT_FILE"`
+	KeyFile      string              `yaml:"key_file" env:"GRPC_KEY_FILE"`
+	ClientCAFile string              `yaml:"client_ca_file" env:"GRPC_CLIENT_CA_FILE"`
+	AuthTokens   map[string]string   `yaml:"auth_tokens" env:"GRPC_AUTH_TOKENS"`
+	Permissions  map[string][]string `yaml:"permissions"`
+}
+
+// Retention задает, сколько хранятся журнал событий для возобновления потока
+// и отметки принятых уведомлений. ProcessedNotifications должен перекрывать
+// время, за которое процессор повторяет недоставленное уведомление, иначе
+// поздний повтор будет принят второй раз.
+type Retention struct {
+	NotificationEvents     time.Duration `yaml:"notification_events" env:"RETENTION_NOTIFICATION_EVENTS" env-default:"168h"`
+	ProcessedNotifications time.Duration `yaml:"processed_notifications" env:"RETENTION_PROCESSED_NOTIFICATIONS" env-default:"168h"`
+	CleanupInterval        time.Duration `yaml:"cleanup_interval" env:"RETENTION_CLEANUP_INTERVAL" env-default:"1h"`
+}
+
 type Database struct {
 	DBname     string `yaml:"db_name"`
 	DBpassword string `yaml:"db_password"`
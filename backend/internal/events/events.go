@@ -0,0 +1,308 @@
+// Package events описывает сообщения, которые получают клиенты WebSocket и SSE.
+// Схема в api/events.schema.json генерируется из этих типов, поэтому любое
+// несовместимое изменение полей должно сопровождаться увеличением Version.
+package events
+
+import "time"
+
+//go:generate go run ../../cmd/eventschema -o ../../api/events.schema.json
+
+const Version = 1
+
+const (
+	TypeConnection          = "connection"
+	TypeSubscriptions       = "subscriptions"
+	TypeSubscriptionError   = "subscription_error"
+	TypeResumeComplete      = "resume_complete"
+	TypeStatusUpdate        = "status_update"
+	TypeFlightStarted       = "flight_started"
+	TypePositionUpdate      = "position_update"
+	TypeFlightCompleted     = "flight_completed"
+	TypeRestrictedZoneAlert = "restricted_zone_alert"
+	TypeFlightPaused        = "flight_paused"
+	TypeFlightResumed       = "flight_resumed"
+	TypeTrafficConflict     = "traffic_conflict"
+	TypeRouteDeviation      = "route_deviation"
+	TypeWaypointAction      = "waypoint_action"
+	TypeReplayStarted       = "replay_started"
+	TypeReplayPaused        = "replay_paused"
+	TypeReplayResumed       = "replay_resumed"
+	TypeReplaySpeedChanged  = "replay_speed_changed"
+	TypeReplaySeeked        = "replay_seeked"
+	TypeReplayFinished      = "replay_finished"
+	TypeReplayError         = "replay_error"
+)
+
+// Header есть в каждом сообщении. EventId выставляет хаб для событий,
+// сохраненных в журнале; Replay помечает кадры воспроизведения записи.
+type Header struct {
+	EventId int64  `json:"event_id,omitempty"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	Replay  bool   `json:"replay,omitempty"`
+}
+
+func NewHeader(eventType string) Header {
+	return Header{Type: eventType, Version: Version}
+}
+
+// Typed реализуют все сообщения со встроенным Header.
+type Typed interface {
+	EventType() string
+}
+
+func (h Header) EventType() string {
+	return h.Type
+}
+
+type Position struct {
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	Altitude      float64   `json:"altitude"`
+	Speed         float64   `json:"speed"`
+	Heading       float64   `json:"heading"`
+	RouteProgress float64   `json:"route_progress"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type RoutePoint struct {
+	Id            int      `json:"id"`
+	Latitude      float64  `json:"latitude"`
+	Longitude     float64  `json:"longitude"`
+	Altitude      float64  `json:"altitude"`
+	PointOrder    int      `json:"point_order"`
+	ApplicationId int      `json:"application_id"`
+	Actions       []Action `json:"actions,omitempty"`
+}
+
+type Action struct {
+	Type    string  `json:"type"`
+	Seconds float64 `json:"seconds,omitempty"`
+	Radius  float64 `json:"radius,omitempty"`
+	Turns   float64 `json:"turns,omitempty"`
+	Speed   float64 `json:"speed,omitempty"`
+}
+
+type Subscription struct {
+	Topic         string  `json:"topic"`
+	ApplicationId int     `json:"application_id,omitempty"`
+	DroneId       int     `json:"drone_id,omitempty"`
+	MinLatitude   float64 `json:"min_latitude,omitempty"`
+	MinLongitude  float64 `json:"min_longitude,omitempty"`
+	MaxLatitude   float64 `json:"max_latitude,omitempty"`
+	MaxLongitude  float64 `json:"max_longitude,omitempty"`
+}
+
+type Connection struct {
+	Header
+	Message       string         `json:"message"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Clients       int            `json:"clients"`
+	UserId        int            `json:"user_id"`
+	Role          string         `json:"role"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+type Subscriptions struct {
+	Header
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+type SubscriptionError struct {
+	Header
+	Message string `json:"message"`
+}
+
+type ResumeComplete struct {
+	Header
+	LastEventId int64     `json:"last_event_id"`
+	Replayed    int       `json:"replayed"`
+	Truncated   bool      `json:"truncated"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type StatusUpdate struct {
+	Header
+	ApplicationId   int       `json:"application_id"`
+	Status          string    `json:"status"`
+	Message         string    `json:"message"`
+	RejectionReason string    `json:"rejection_reason"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type FlightStarted struct {
+	Header
+	ApplicationId    int          `json:"application_id"`
+	DroneId          int          `json:"drone_id"`
+	PilotId          int          `json:"pilot_id"`
+	Route            []RoutePoint `json:"route"`
+	CurrentPosition  *Position    `json:"current_position"`
+	StartTime        time.Time    `json:"start_time"`
+	EstimatedEndTime *time.Time   `json:"estimated_end_time,omitempty"`
+	Message          string       `json:"message,omitempty"`
+}
+
+type PositionUpdate struct {
+	Header
+	ApplicationId     int        `json:"application_id"`
+	DroneId           int        `json:"drone_id"`
+	Latitude          float64    `json:"latitude"`
+	Longitude         float64    `json:"longitude"`
+	Altitude          float64    `json:"altitude"`
+	Speed             float64    `json:"speed"`
+	Heading           float64    `json:"heading"`
+	RouteProgress     float64    `json:"route_progress"`
+	Timestamp         time.Time  `json:"timestamp"`
+	EstimatedEndTime  *time.Time `json:"estimated_end_time,omitempty"`
+	DistanceRemaining float64    `json:"distance_remaining"`
+}
+
+type FlightCompleted struct {
+	Header
+	ApplicationId    int       `json:"application_id"`
+	DroneId          int       `json:"drone_id"`
+	FinalPosition    *Position `json:"final_position"`
+	CompletionTime   time.Time `json:"completion_time"`
+	CompletionStatus string    `json:"completion_status"`
+	Message          string    `json:"message,omitempty"`
+}
+
+type RestrictedZoneAlert struct {
+	Header
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	ZoneName      string    `json:"zone_name"`
+	ZoneLatitude  float64   `json:"zone_latitude"`
+	ZoneLongitude float64   `json:"zone_longitude"`
+	ZoneRadius    int       `json:"zone_radius"`
+	AlertLevel    string    `json:"alert_level"`
+	Distance      float64   `json:"distance"`
+	DronePosition *Position `json:"drone_position"`
+	Timestamp     time.Time `json:"timestamp"`
+	Message       string    `json:"message,omitempty"`
+}
+
+type FlightPaused struct {
+	Header
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	PausePosition *Position `json:"pause_position"`
+	PauseTime     time.Time `json:"pause_time"`
+	PauseReason   string    `json:"pause_reason"`
+}
+
+type FlightResumed struct {
+	Header
+	ApplicationId  int       `json:"application_id"`
+	DroneId        int       `json:"drone_id"`
+	ResumePosition *Position `json:"resume_position"`
+	ResumeTime     time.Time `json:"resume_time"`
+	ResumeReason   string    `json:"resume_reason"`
+}
+
+type TrafficConflict struct {
+	Header
+	ApplicationId         int       `json:"application_id"`
+	DroneId               int       `json:"drone_id"`
+	IntruderApplicationId int       `json:"intruder_application_id"`
+	IntruderDroneId       int       `json:"intruder_drone_id"`
+	AlertLevel            string    `json:"alert_level"`
+	HorizontalSeparation  float64   `json:"horizontal_separation"`
+	VerticalSeparation    float64   `json:"vertical_separation"`
+	Resolution            string    `json:"resolution"`
+	DronePosition         *Position `json:"drone_position"`
+	IntruderPosition      *Position `json:"intruder_position"`
+	Timestamp             time.Time `json:"timestamp"`
+	Message               string    `json:"message,omitempty"`
+}
+
+type RouteDeviation struct {
+	Header
+	ApplicationId        int       `json:"application_id"`
+	DroneId              int       `json:"drone_id"`
+	DeviationType        string    `json:"deviation_type"`
+	AlertLevel           string    `json:"alert_level"`
+	LateralDeviation     float64   `json:"lateral_deviation"`
+	VerticalDeviation    float64   `json:"vertical_deviation"`
+	TimeDeviationSeconds float64   `json:"time_deviation_seconds"`
+	Leg                  int       `json:"leg"`
+	DronePosition        *Position `json:"drone_position"`
+	Timestamp            time.Time `json:"timestamp"`
+	Message              string    `json:"message,omitempty"`
+}
+
+// WaypointAction сообщает о действии в точке маршрута. Phase — started или
+// completed; мгновенные действия приходят только с completed.
+type WaypointAction struct {
+	Header
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	Waypoint      int       `json:"waypoint"`
+	Action        Action    `json:"action"`
+	Phase         string    `json:"phase"`
+	DronePosition *Position `json:"drone_position"`
+	Timestamp     time.Time `json:"timestamp"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// FlightEvent — событие истории полета, для которого нет отдельного типа
+// сообщения. Type в заголовке совпадает с типом, записанным процессором.
+type FlightEvent struct {
+	Header
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	AlertLevel    string    `json:"alert_level,omitempty"`
+	DronePosition *Position `json:"drone_position"`
+	Timestamp     time.Time `json:"timestamp"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// ReplayStatus отправляется на каждое изменение состояния воспроизведения:
+// replay_started, replay_paused, replay_resumed, replay_speed_changed,
+// replay_seeked и replay_finished.
+type ReplayStatus struct {
+	Header
+	ApplicationId   int       `json:"application_id"`
+	Speed           float64   `json:"speed"`
+	PositionSeconds float64   `json:"position_seconds"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+}
+
+type ReplayError struct {
+	Header
+	ApplicationId int    `json:"application_id"`
+	Message       string `json:"message"`
+}
+
+// Catalog перечисляет все типы сообщений и служит источником для JSON Schema.
+func Catalog() map[string]interface{} {
+	replayStatus := ReplayStatus{}
+
+	return map[string]interface{}{
+		TypeConnection:          Connection{},
+		TypeSubscriptions:       Subscriptions{},
+		TypeSubscriptionError:   SubscriptionError{},
+		TypeResumeComplete:      ResumeComplete{},
+		TypeStatusUpdate:        StatusUpdate{},
+		TypeFlightStarted:       FlightStarted{},
+		TypePositionUpdate:      PositionUpdate{},
+		TypeFlightCompleted:     FlightCompleted{},
+		TypeRestrictedZoneAlert: RestrictedZoneAlert{},
+		TypeFlightPaused:        FlightPaused{},
+		TypeFlightResumed:       FlightResumed{},
+		TypeTrafficConflict:     TrafficConflict{},
+		TypeRouteDeviation:      RouteDeviation{},
+		TypeWaypointAction:      WaypointAction{},
+		TypeReplayStarted:       replayStatus,
+		TypeReplayPaused:        replayStatus,
+		TypeReplayResumed:       replayStatus,
+		TypeReplaySpeedChanged:  replayStatus,
+		TypeReplaySeeked:        replayStatus,
+		TypeReplayFinished:      replayStatus,
+		TypeReplayError:         ReplayError{},
+	}
+}
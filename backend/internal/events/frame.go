@@ -0,0 +1,53 @@
+package events
+
+import (
+	"encoding/json"
+
+	pb "github.com/nxbodyevzncvre/decenthack/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EncodeFrame переводит JSON-сообщение в бинарный EventFrame.
+func EncodeFrame(message []byte) ([]byte, error) {
+	var header Header
+	if err := json.Unmarshal(message, &header); err != nil {
+		return nil, err
+	}
+
+	frame := &pb.EventFrame{
+		Type:    header.Type,
+		Version: uint32(header.Version),
+		EventId: header.EventId,
+		Replay:  header.Replay,
+	}
+
+	if header.Type == TypePositionUpdate {
+		var position PositionUpdate
+		if err := json.Unmarshal(message, &position); err != nil {
+			return nil, err
+		}
+
+		event := &pb.PositionUpdateEvent{
+			ApplicationId:     int32(position.ApplicationId),
+			DroneId:           int32(position.DroneId),
+			Latitude:          position.Latitude,
+			Longitude:         position.Longitude,
+			Altitude:          position.Altitude,
+			Speed:             position.Speed,
+			Heading:           position.Heading,
+			RouteProgress:     position.RouteProgress,
+			Timestamp:         timestamppb.New(position.Timestamp),
+			DistanceRemaining: position.DistanceRemaining,
+		}
+		if position.EstimatedEndTime != nil {
+			event.EstimatedEndTime = timestamppb.New(*position.EstimatedEndTime)
+		}
+
+		frame.Body = &pb.EventFrame_PositionUpdate{PositionUpdate: event}
+	} else {
+		frame.Body = &pb.EventFrame_Json{Json: message}
+	}
+
+	return proto.Marshal(frame)
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const schemaId = "https://decenthack/api/events.schema.json"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema строит JSON Schema (draft 2020-12) для всех сообщений из Catalog.
+// Сообщения различаются по полю type.
+func JSONSchema() ([]byte, error) {
+	catalog := Catalog()
+
+	eventTypes := make([]string, 0, len(catalog))
+	for eventType := range catalog {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	defs := map[string]interface{}{}
+	oneOf := make([]interface{}, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		schema := structSchema(reflect.TypeOf(catalog[eventType]), defs)
+		properties := schema["properties"].(map[string]interface{})
+		properties["type"] = map[string]interface{}{"const": eventType}
+		properties["version"] = map[string]interface{}{"const": Version}
+
+		defs[eventType] = schema
+		oneOf = append(oneOf, map[string]interface{}{"$ref": "#/$defs/" + eventType})
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     schemaId,
+		"title":   "Flight notification events",
+		"version": Version,
+		"oneOf":   oneOf,
+		"$defs":   defs,
+	}, "", "  ")
+}
+
+func structSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	collectFields(t, properties, &required, defs)
+	sort.Strings(required)
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func collectFields(t reflect.Type, properties map[string]interface{}, required *[]string, defs map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			collectFields(field.Type, properties, required, defs)
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		properties[name] = typeSchema(field.Type, defs)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func typeSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := typeSchema(t.Elem(), defs)
+		return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := defs[name]; !ok {
+			defs[name] = structSchema(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + name}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs)}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
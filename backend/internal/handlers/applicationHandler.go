@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/internal/structures"
 )
@@ -24,33 +29,212 @@ func (a *ApplicationHandler) CreateApplication(c *fiber.Ctx) error {
 
 	var req structures.CreateApplicationRequest
 	if err := c.BodyParser(&req); err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error parsing body"})
+		return apperror.Validation("Error parsing body")
+	}
+
+	if err := validateActions(req.Actions); err != nil {
+		return err
 	}
 
 	req.PilotId = pilotId
+	req.Status = structures.StatusPending
 
 	err := a.repo.CreateApplication(req)
 	if err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error with creating application"})
+		return apperror.Internal("Error with creating application", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"success": "application has been uploaded"})
 }
 
+// UpdateApplication меняет маршрут, время или дрон заявки, пока полет не
+// начался. Заявка возвращается в pending и проходит проверку заново.
+func (a *ApplicationHandler) UpdateApplication(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req structures.CreateApplicationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("Error parsing body")
+	}
+
+	if err := validateActions(req.Actions); err != nil {
+		return err
+	}
+
+	if err := a.checkOwner(id, pilotId); err != nil {
+		return err
+	}
+
+	revision, err := a.repo.AmendApplication(id, pilotId, req)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Application not found")
+	}
+	if errors.Is(err, repository.ErrInvalidState) {
+		return apperror.Conflict("Application can only be changed before the flight starts")
+	}
+	if errors.Is(err, repository.ErrSurveyMission) {
+		return apperror.Conflict("Survey application route cannot be changed, delete it and create a new survey")
+	}
+	if err != nil {
+		return apperror.Internal("Error with updating application", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{"success": "application has been updated", "revision": revision})
+}
+
+// ApplicationRevisions возвращает историю изменений заявки.
+func (a *ApplicationHandler) ApplicationRevisions(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := a.checkOwner(id, pilotId); err != nil {
+		return err
+	}
+
+	application, err := a.repo.SelectApplication(id)
+	if err != nil {
+		return apperror.Internal("Error with getting application", err)
+	}
+
+	revisions, err := a.repo.SelectApplicationRevisions(id)
+	if err != nil {
+		return apperror.Internal("Error with getting application revisions", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"application_id":   id,
+		"current_revision": application.Revision,
+		"revisions":        revisions,
+	})
+}
+
+func (a *ApplicationHandler) checkOwner(id, pilotId int) error {
+	owner, err := a.repo.SelectApplicationPilot(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Application not found")
+	}
+	if err != nil {
+		return apperror.Internal("Error with getting application", err)
+	}
+
+	if owner != pilotId {
+		return apperror.Forbidden("Application belongs to another pilot")
+	}
+
+	return nil
+}
+
+// validateActions проверяет параметры, обязательные для типа действия:
+// схема OpenAPI описывает поля, но не их зависимость от type.
+func validateActions(actions []structures.WaypointAction) error {
+	var fields []apperror.FieldError
+	for i, action := range actions {
+		field := fmt.Sprintf("body.actions[%d]", i)
+
+		switch action.Type {
+		case structures.ActionHover:
+			if action.Seconds <= 0 {
+				fields = append(fields, apperror.FieldError{Field: field + ".seconds", Message: "must be > 0 for hover"})
+			}
+		case structures.ActionOrbit:
+			if action.Radius <= 0 {
+				fields = append(fields, apperror.FieldError{Field: field + ".radius", Message: "must be > 0 for orbit"})
+			}
+		case structures.ActionChangeSpeed:
+			if action.Speed <= 0 {
+				fields = append(fields, apperror.FieldError{Field: field + ".speed", Message: "must be > 0 for change_speed"})
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation("Invalid waypoint actions", fields...)
+	}
+
+	return nil
+}
+
 func (a *ApplicationHandler) DeleteApplication(c *fiber.Ctx) error {
-	id, _ := strconv.Atoi(c.Params("id"))
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := a.checkOwner(id, pilotId); err != nil {
+		return err
+	}
 
-	err := a.repo.DeleteApplication(id)
+	err = a.repo.DeleteApplication(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Application not found")
+	}
+	if errors.Is(err, repository.ErrInvalidState) {
+		return apperror.Conflict("Application is being processed or flown, cancel it and wait for the drone to land")
+	}
 	if err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error with deleting application"})
+		return apperror.Internal("Error with deleting application", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"success": "Application deleted successfully"})
 }
 
+// CancelApplication отменяет заявку, не удаляя ее. Выполняемый полет
+// прерывает процессор, поэтому для него ответ 202 и статус пока executing.
+func (a *ApplicationHandler) CancelApplication(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req structures.CancelApplicationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("Error parsing body")
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return apperror.Validation("Invalid reason", apperror.FieldError{Field: "body.reason", Message: "must not be empty"})
+	}
+
+	if req.Command == "" {
+		req.Command = structures.FlightCommandReturnHome
+	}
+
+	if err := a.checkOwner(id, pilotId); err != nil {
+		return err
+	}
+
+	status, err := a.repo.CancelApplication(id, pilotId, req.Command, req.Reason)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Application not found")
+	}
+	if errors.Is(err, repository.ErrInvalidState) {
+		return apperror.Conflict("Application is already finished")
+	}
+	if err != nil {
+		return apperror.Internal("Error with cancelling application", err)
+	}
+
+	if status == structures.StatusExecuting {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"success": "cancellation has been requested", "status": status})
+	}
+
+	return c.Status(200).JSON(fiber.Map{"success": "application has been cancelled", "status": status})
+}
+
 func (a *ApplicationHandler) ApplicationStatus(c *fiber.Ctx) error {
 	return nil
 }
@@ -58,10 +242,116 @@ func (a *ApplicationHandler) ApplicationStatus(c *fiber.Ctx) error {
 func (a *ApplicationHandler) AllApplications(c *fiber.Ctx) error {
 	pilotId, _ := c.Locals("userId").(int)
 
-	applications, err := a.repo.AllPilotsAplications(pilotId)
+	page, err := parsePage(c, listing.ApplicationSorts...)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseApplicationFilter(c)
+	if err != nil {
+		return err
+	}
+	filter.PilotId = pilotId
+
+	applications, err := a.repo.AllPilotsAplications(filter, page)
+	if err != nil {
+		return apperror.Internal("Error with getting all applications", err)
+	}
+
+	applications, next := listing.Paginate(page, applications, listing.ApplicationKey(page))
+
+	return c.Status(200).JSON(fiber.Map{"applications": applications, "next_cursor": next})
+}
+
+// parseApplicationFilter читает status (через запятую), drone_id и интервал
+// from/to по дате начала.
+func parseApplicationFilter(c *fiber.Ctx) (repository.ApplicationFilter, error) {
+	query := listing.ApplicationQuery{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	}
+
+	if value := c.Query("status"); value != "" {
+		query.Statuses = strings.Split(value, ",")
+	}
+
+	if value := c.Query("drone_id"); value != "" {
+		droneId, err := strconv.Atoi(value)
+		if err != nil || droneId <= 0 {
+			return repository.ApplicationFilter{}, apperror.Validation("Invalid filter", apperror.FieldError{
+				Field:   "query.drone_id",
+				Message: "must be a positive integer",
+			})
+		}
+		query.DroneId = droneId
+	}
+
+	return listing.ParseApplicationFilter(query)
+}
+
+func (a *ApplicationHandler) ApplicationTrack(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	from := time.Unix(0, 0).UTC()
+	to := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return apperror.Validation("Invalid 'from'", apperror.FieldError{Field: "query.from", Message: "must be an RFC3339 date-time"})
+		}
+	}
+
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return apperror.Validation("Invalid 'to'", apperror.FieldError{Field: "query.to", Message: "must be an RFC3339 date-time"})
+		}
+	}
+
+	maxPoints := c.QueryInt("max_points", 0)
+	if maxPoints < 0 {
+		return apperror.Validation("Invalid 'max_points'", apperror.FieldError{Field: "query.max_points", Message: "must be >= 0"})
+	}
+
+	if err := a.checkOwner(id, pilotId); err != nil {
+		return err
+	}
+
+	track, err := a.repo.SelectTrack(id, from.UTC(), to.UTC())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with getting all applications"})
+		return apperror.Internal("Error with getting flight track", err)
+	}
+
+	total := len(track)
+	track = downsampleTrack(track, maxPoints)
+
+	return c.Status(200).JSON(fiber.Map{
+		"application_id": id,
+		"total_points":   total,
+		"track":          track,
+	})
+}
+
+// downsampleTrack оставляет не больше maxPoints точек с равным шагом,
+// всегда сохраняя первую и последнюю точку трека.
+func downsampleTrack(track []structures.TrackPoint, maxPoints int) []structures.TrackPoint {
+	if maxPoints <= 0 || len(track) <= maxPoints {
+		return track
+	}
+
+	if maxPoints == 1 {
+		return track[len(track)-1:]
+	}
+
+	result := make([]structures.TrackPoint, 0, maxPoints)
+	step := float64(len(track)-1) / float64(maxPoints-1)
+	for i := 0; i < maxPoints; i++ {
+		result = append(result, track[int(float64(i)*step+0.5)])
 	}
 
-	return c.Status(200).JSON(fiber.Map{"applications": applications})
+	return result
 }
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
+	"github.com/nxbodyevzncvre/decenthack/internal/recurrence"
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// maxSeriesOccurrences — сколько заявок может породить одна серия: год
+// ежедневных полетов.
+const maxSeriesOccurrences = 366
+
+// occurrenceLayout — формат, в котором даты вхождений пишутся в заявки.
+const occurrenceLayout = "2006-01-02 15:04:05"
+
+// CreateSeries разворачивает правило повторения в отдельные заявки. Каждую
+// процессор проверяет незадолго до ее начала, по зонам, действующим на тот
+// момент.
+func (a *ApplicationHandler) CreateSeries(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	var req structures.CreateSeriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("Error parsing body")
+	}
+
+	if err := validateActions(req.Actions); err != nil {
+		return err
+	}
+
+	rule, err := recurrence.Parse(req.RRule)
+	if err != nil {
+		return apperror.Validation("Invalid recurrence rule", apperror.FieldError{Field: "body.rrule", Message: err.Error()})
+	}
+
+	start, err := listing.ParseLocalDateTime(req.StartDate)
+	if err != nil {
+		return apperror.Validation("Invalid start_date", apperror.FieldError{Field: "body.start_date", Message: "must be a date-time like 2025-05-01T10:00"})
+	}
+
+	end, err := listing.ParseLocalDateTime(req.EndDate)
+	if err != nil || !end.After(start) {
+		return apperror.Validation("Invalid end_date", apperror.FieldError{Field: "body.end_date", Message: "must be a date-time after start_date"})
+	}
+
+	starts, err := rule.Occurrences(start, maxSeriesOccurrences)
+	if errors.Is(err, recurrence.ErrTooManyOccurrences) {
+		return apperror.Validation("Too many occurrences", apperror.FieldError{Field: "body.rrule", Message: "must produce at most 366 occurrences"})
+	}
+	if len(starts) == 0 {
+		return apperror.Validation("Empty series", apperror.FieldError{Field: "body.rrule", Message: "produces no occurrences"})
+	}
+
+	duration := end.Sub(start)
+	occurrences := make([]structures.Occurrence, 0, len(starts))
+	for _, occurrenceStart := range starts {
+		occurrences = append(occurrences, structures.Occurrence{
+			StartDate: occurrenceStart.Format(occurrenceLayout),
+			EndDate:   occurrenceStart.Add(duration).Format(occurrenceLayout),
+		})
+	}
+
+	req.PilotId = pilotId
+
+	series, err := a.repo.CreateSeries(req, occurrences)
+	if err != nil {
+		return apperror.Internal("Error with creating application series", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{"series": series})
+}
+
+func (a *ApplicationHandler) Series(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	series, err := a.ownSeries(id, pilotId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{"series": series})
+}
+
+// CancelSeries отменяет все вхождения серии, которые еще не взлетели.
+func (a *ApplicationHandler) CancelSeries(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req structures.SeriesReasonRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("Error parsing body")
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return apperror.Validation("Invalid reason", apperror.FieldError{Field: "body.reason", Message: "must not be empty"})
+	}
+
+	if _, err := a.ownSeries(id, pilotId); err != nil {
+		return err
+	}
+
+	cancelled, err := a.repo.CancelSeries(id, req.Reason)
+	if errors.Is(err, repository.ErrInvalidState) {
+		return apperror.Conflict("Series is already cancelled")
+	}
+	if err != nil {
+		return apperror.Internal("Error with cancelling application series", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{"success": "series has been cancelled", "cancelled_occurrences": cancelled})
+}
+
+// SkipOccurrence отменяет одно вхождение, не затрагивая остальные.
+func (a *ApplicationHandler) SkipOccurrence(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
+
+	occurrenceId, err := paramId(c, "occurrence")
+	if err != nil {
+		return err
+	}
+
+	var req structures.SeriesReasonRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return apperror.Validation("Error parsing body")
+		}
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		req.Reason = "Occurrence skipped by pilot"
+	}
+
+	if _, err := a.ownSeries(id, pilotId); err != nil {
+		return err
+	}
+
+	err = a.repo.SkipOccurrence(id, occurrenceId, req.Reason)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Occurrence not found in series")
+	}
+	if errors.Is(err, repository.ErrInvalidState) {
+		return apperror.Conflict("Occurrence has already started or finished")
+	}
+	if err != nil {
+		return apperror.Internal("Error with skipping occurrence", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{"success": "occurrence has been skipped"})
+}
+
+func (a *ApplicationHandler) ownSeries(id, pilotId int) (*structures.ApplicationSeries, error) {
+	series, err := a.repo.SelectSeries(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Series not found")
+	}
+	if err != nil {
+		return nil, apperror.Internal("Error with getting application series", err)
+	}
+
+	if series.PilotId != pilotId {
+		return nil, apperror.Forbidden("Series belongs to another pilot")
+	}
+
+	return series, nil
+}
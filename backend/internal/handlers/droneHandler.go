@@ -1,11 +1,12 @@
 package handlers
 
 import (
-	"strconv"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/internal/structures"
 )
@@ -23,48 +24,71 @@ func (d *DroneHandler) CreateDrone(c *fiber.Ctx) error {
 	drone := new(structures.Drone)
 
 	if err := c.BodyParser(drone); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with parsing body"})
+		return apperror.Validation("Error with parsing body")
 	}
 
 	if drone.Brand_name == "" || drone.Model_name == "" || drone.Serial_number == "" {
-		return c.Status(500).JSON(fiber.Map{"error": "All fields required"})
+		return apperror.Validation("All fields required")
 	}
 
 	err := d.repo.InsertDrone(drone)
+	if errors.Is(err, repository.ErrConflict) {
+		return apperror.Conflict("Drone with this serial number already exists")
+	}
 	if err != nil {
-		log.Error(err)
-		return c.Status(200).JSON(fiber.Map{"error": "Error with database"})
+		return apperror.Internal("Error with database", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"message": "Drone has been added"})
 }
 
 func (d *DroneHandler) DroneById(c *fiber.Ctx) error {
-	id, _ := strconv.Atoi(c.Params("id"))
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
 
 	drone, err := d.repo.SelectDroneById(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Drone not found")
+	}
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with selecting drone"})
+		return apperror.Internal("Error with selecting drone", err)
 	}
+
 	return c.Status(200).JSON(fiber.Map{"drone": drone})
 }
 
 func (d *DroneHandler) AllDrones(c *fiber.Ctx) error {
-	drones, err := d.repo.SelectAllDrones()
+	page, err := parsePage(c, "id")
+	if err != nil {
+		return err
+	}
 
+	drones, err := d.repo.SelectAllDrones(page)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with selecting drone"})
+		return apperror.Internal("Error with selecting drone", err)
 	}
 
-	return c.Status(200).JSON(fiber.Map{"drone": drones})
+	drones, next := listing.Paginate(page, drones, func(drone structures.Drone) (int, string) {
+		return drone.Id, ""
+	})
+
+	return c.Status(200).JSON(fiber.Map{"drone": drones, "next_cursor": next})
 }
 
 func (d *DroneHandler) DeleteDrone(c *fiber.Ctx) error {
-	id, _ := strconv.Atoi(c.Params("id"))
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
 
-	err := d.repo.DeleteDrone(id)
+	err = d.repo.DeleteDrone(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Drone not found")
+	}
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with deleting drone"})
+		return apperror.Internal("Error with deleting drone", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"success": "Drone has been deleted"})
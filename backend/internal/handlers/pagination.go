@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+)
+
+// parsePage читает limit, cursor, sort и order из query. Проверка и курсор
+// общие с gRPC, см. listing.ParsePage.
+func parsePage(c *fiber.Ctx, sorts ...string) (repository.Page, error) {
+	query := listing.PageQuery{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return repository.Page{}, apperror.Validation("Invalid 'limit'", apperror.FieldError{
+				Field:   "query.limit",
+				Message: "must be an integer between 1 and " + strconv.Itoa(listing.MaxLimit),
+			})
+		}
+		query.Limit = limit
+	}
+
+	return listing.ParsePage(query, sorts...)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+)
+
+// paramId читает положительный целый параметр пути.
+func paramId(c *fiber.Ctx, name string) (int, error) {
+	id, err := strconv.Atoi(c.Params(name))
+	if err != nil || id <= 0 {
+		return 0, apperror.Validation("Invalid "+name, apperror.FieldError{
+			Field:   "path." + name,
+			Message: "must be a positive integer",
+		})
+	}
+
+	return id, nil
+}
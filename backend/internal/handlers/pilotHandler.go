@@ -1,13 +1,14 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/internal/structures"
 	generatetoken "github.com/nxbodyevzncvre/decenthack/pkg/jwt/generateToken"
-	"github.com/nxbodyevzncvre/decenthack/pkg/logger/sl"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -24,26 +25,28 @@ func (p *PilotHandler) SignIn(c *fiber.Ctx) error {
 	req := new(structures.Pilot)
 
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with parsing body"})
+		return apperror.Validation("Error with parsing body")
 	}
 
 	if req.Password == "" || req.Phone == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "username and password are required"})
+		return apperror.Validation("phone and password are required")
 	}
 
 	pilot, err := p.repo.SelectPilot(req.Phone)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.Unauthorized("Invalid phone or password")
+	}
 	if err != nil {
-		log.Error(sl.Err(err))
-		return c.Status(500).JSON(fiber.Map{"error": "Pilot not found"})
+		return apperror.Internal("Error with selecting pilot", err)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(pilot.Password), []byte(req.Password)); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Invalid password"})
+		return apperror.Unauthorized("Invalid phone or password")
 	}
 
-	accessToken, err := generatetoken.GenerateAccessToken(pilot.Id, p.cfg.JWTSecretKey)
+	accessToken, err := generatetoken.GenerateAccessToken(pilot.Id, string(pilot.Role), p.cfg.JWTSecretKey)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with generating JWT"})
+		return apperror.Internal("Error with generating JWT", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"access_token": accessToken})
@@ -53,28 +56,31 @@ func (p *PilotHandler) SignUp(c *fiber.Ctx) error {
 	pilot := new(structures.Pilot)
 
 	if err := c.BodyParser(pilot); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Error with parsing body"})
+		return apperror.Validation("Error with parsing body")
 	}
 
 	if pilot.Firstname == "" || pilot.Lastname == "" || pilot.Middlename == "" || pilot.Password == "" || pilot.Phone == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "All fields required"})
+		return apperror.Validation("All fields required")
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(pilot.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with hash"})
+		return apperror.Internal("Error with hash", err)
 	}
 
 	pilot.Password = string(hash)
 
 	pilotId, err := p.repo.InsertPilot(pilot)
+	if errors.Is(err, repository.ErrConflict) {
+		return apperror.Conflict("Pilot with this phone already exists")
+	}
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with inserting data"})
+		return apperror.Internal("Error with inserting data", err)
 	}
 
-	accessToken, err := generatetoken.GenerateAccessToken(pilotId, p.cfg.JWTSecretKey)
+	accessToken, err := generatetoken.GenerateAccessToken(pilotId, string(structures.RolePilot), p.cfg.JWTSecretKey)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with generating JWT"})
+		return apperror.Internal("Error with generating JWT", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"access_token": accessToken})
@@ -84,12 +90,15 @@ func (p *PilotHandler) PilotById(c *fiber.Ctx) error {
 	pilotId, _ := c.Locals("userId").(int)
 
 	pilot, err := p.repo.SelectPilotById(pilotId)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Pilot not found")
+	}
 	if err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error with selecting pilot by id"})
+		return apperror.Internal("Error with selecting pilot by id", err)
 	}
 
 	pilot.Id = pilotId
+	pilot.Password = ""
 
 	return c.Status(200).JSON(fiber.Map{"pilot": pilot})
 }
@@ -1,11 +1,12 @@
 package handlers
 
 import (
-	"strconv"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/internal/structures"
 )
@@ -23,34 +24,55 @@ func (z *ZonesHandler) CreateZone(c *fiber.Ctx) error {
 	zone := new(structures.RestrictedZone)
 
 	if err := c.BodyParser(zone); err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error with parsing body"})
+		return apperror.Validation("Error with parsing body")
 	}
 
 	err := z.repo.InsertZone(zone)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with inserting zone"})
+		return apperror.Internal("Error with inserting zone", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"zone": zone})
 }
 
 func (z *ZonesHandler) AllZones(c *fiber.Ctx) error {
-	zones, err := z.repo.GetAllZones()
+	page, err := parsePage(c, "id")
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error with getting all zones"})
+		return err
 	}
 
-	return c.Status(200).JSON(fiber.Map{"zones": zones})
+	var filter repository.ZoneFilter
+	if value := c.Query("bbox"); value != "" {
+		filter.BoundingBox, err = listing.ParseBoundingBox(value)
+		if err != nil {
+			return apperror.Validation("Invalid 'bbox'", apperror.FieldError{Field: "query.bbox", Message: err.Error()})
+		}
+	}
+
+	zones, err := z.repo.GetAllZones(filter, page)
+	if err != nil {
+		return apperror.Internal("Error with getting all zones", err)
+	}
+
+	zones, next := listing.Paginate(page, zones, func(zone structures.RestrictedZone) (int, string) {
+		return zone.Id, ""
+	})
+
+	return c.Status(200).JSON(fiber.Map{"zones": zones, "next_cursor": next})
 }
 
 func (z *ZonesHandler) DeleteZone(c *fiber.Ctx) error {
-	id, _ := strconv.Atoi(c.Params("id"))
+	id, err := paramId(c, "id")
+	if err != nil {
+		return err
+	}
 
-	err := z.repo.DeleteZone(id)
+	err = z.repo.DeleteZone(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Zone not found")
+	}
 	if err != nil {
-		log.Error(err)
-		return c.Status(500).JSON(fiber.Map{"error": "Error with deleting zone"})
+		return apperror.Internal("Error with deleting zone", err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"success": "Zone has been deleted successfully"})
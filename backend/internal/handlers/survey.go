@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	"github.com/nxbodyevzncvre/decenthack/internal/survey"
+)
+
+// maxSurveyAltitude — верхняя граница высоты облета, та же, что проверяет
+// процессор при согласовании маршрута.
+const maxSurveyAltitude = 500
+
+// CreateSurvey принимает заявку на съемку площади и строит по ней маршрут
+// «змейкой». Точки маршрута сохраняются в Route, как у обычной заявки.
+func (a *ApplicationHandler) CreateSurvey(c *fiber.Ctx) error {
+	pilotId, _ := c.Locals("userId").(int)
+
+	var req structures.CreateSurveyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("Error parsing body")
+	}
+
+	if req.Altitude <= 0 || req.Altitude > maxSurveyAltitude {
+		return apperror.Validation("Invalid altitude", apperror.FieldError{
+			Field:   "body.altitude",
+			Message: fmt.Sprintf("must be > 0 and <= %d", maxSurveyAltitude),
+		})
+	}
+
+	mission := survey.Mission{
+		Polygon:        make([]survey.Point, len(req.Polygon)),
+		FootprintWidth: req.FootprintWidth,
+		Overlap:        req.Overlap,
+	}
+	for i, vertex := range req.Polygon {
+		mission.Polygon[i] = survey.Point{Latitude: vertex.Latitude, Longitude: vertex.Longitude}
+	}
+
+	waypoints, err := survey.Plan(mission)
+	if err != nil {
+		return apperror.Validation("Unable to plan survey", apperror.FieldError{Field: "body", Message: err.Error()})
+	}
+
+	route := make([]structures.Routes, len(waypoints))
+	for i, waypoint := range waypoints {
+		route[i] = structures.Routes{
+			Latitude:    waypoint.Latitude,
+			Longitude:   waypoint.Longitude,
+			Altitude:    req.Altitude,
+			Point_order: i,
+		}
+	}
+
+	req.PilotId = pilotId
+
+	applicationId, err := a.repo.CreateSurveyApplication(req, route)
+	if err != nil {
+		return apperror.Internal("Error with creating survey application", err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"success":        "application has been uploaded",
+		"application_id": applicationId,
+		"line_spacing":   mission.Spacing(),
+		"waypoints":      len(route),
+	})
+}
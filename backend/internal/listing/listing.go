@@ -0,0 +1,262 @@
+// Package listing разбирает параметры списков — страницу, сортировку и
+// фильтры — одинаково для REST и gRPC, чтобы курсор, выданный одним API,
+// принимался другим.
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 100
+)
+
+// DateTimeLayout — формат DATETIME в MySQL, в нем же сравниваются даты фильтров.
+const DateTimeLayout = "2006-01-02 15:04:05.999999"
+
+// PageQuery — параметры страницы в том виде, в каком их прислал клиент.
+// Limit 0 — размер страницы по умолчанию, пустой Order — asc.
+type PageQuery struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Order  string
+}
+
+// cursor — непрозрачная для клиента позиция в списке. Сортировка и порядок
+// зашиты в курсор, чтобы его нельзя было продолжить с другими параметрами.
+type cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Id    int    `json:"id"`
+	Value string `json:"v,omitempty"`
+}
+
+// ParsePage проверяет параметры страницы. Первый из sorts — ключ по
+// умолчанию. Limit страницы на единицу больше запрошенного: лишняя запись
+// показывает, что есть следующая страница.
+func ParsePage(query PageQuery, sorts ...string) (repository.Page, error) {
+	limit := DefaultLimit
+	if query.Limit != 0 {
+		if query.Limit < 1 || query.Limit > MaxLimit {
+			return repository.Page{}, apperror.Validation("Invalid 'limit'", apperror.FieldError{
+				Field:   "query.limit",
+				Message: "must be an integer between 1 and " + strconv.Itoa(MaxLimit),
+			})
+		}
+		limit = query.Limit
+	}
+
+	sort := sorts[0]
+	if query.Sort != "" {
+		if !slices.Contains(sorts, query.Sort) {
+			return repository.Page{}, apperror.Validation("Invalid 'sort'", apperror.FieldError{
+				Field:   "query.sort",
+				Message: "must be one of: " + strings.Join(sorts, ", "),
+			})
+		}
+		sort = query.Sort
+	}
+
+	order := query.Order
+	if order == "" {
+		order = "asc"
+	}
+	if order != "asc" && order != "desc" {
+		return repository.Page{}, apperror.Validation("Invalid 'order'", apperror.FieldError{
+			Field:   "query.order",
+			Message: "must be one of: asc, desc",
+		})
+	}
+
+	page := repository.Page{Limit: limit + 1, Sort: sort, Descending: order == "desc"}
+
+	if query.Cursor != "" {
+		after, err := decodeCursor(query.Cursor)
+		if err != nil || after.Sort != sort || after.Order != order {
+			return repository.Page{}, apperror.Validation("Invalid 'cursor'", apperror.FieldError{
+				Field:   "query.cursor",
+				Message: "must be a next_cursor returned for the same sort and order",
+			})
+		}
+
+		page.After = true
+		page.AfterId = after.Id
+		page.AfterValue = after.Value
+	}
+
+	return page, nil
+}
+
+// Paginate отрезает лишнюю запись и возвращает курсор следующей страницы
+// (пустой, если страница последняя). key возвращает id записи и значение
+// ключа сортировки.
+func Paginate[T any](page repository.Page, items []T, key func(T) (int, string)) ([]T, string) {
+	limit := page.Limit - 1
+	if len(items) <= limit {
+		return items, ""
+	}
+
+	items = items[:limit]
+	id, value := key(items[limit-1])
+
+	order := "asc"
+	if page.Descending {
+		order = "desc"
+	}
+
+	return items, encodeCursor(cursor{Sort: page.Sort, Order: order, Id: id, Value: value})
+}
+
+func encodeCursor(value cursor) string {
+	data, _ := json.Marshal(value)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var result cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+// ApplicationSorts — ключи сортировки списка заявок, первый — по умолчанию.
+var ApplicationSorts = []string{"id", "start_date", "created_at"}
+
+// ApplicationKey возвращает ключ курсора заявки для сортировки страницы.
+func ApplicationKey(page repository.Page) func(structures.AllPitlotsApl) (int, string) {
+	return func(application structures.AllPitlotsApl) (int, string) {
+		switch page.Sort {
+		case "start_date":
+			return application.Id, application.StartDate
+		case "created_at":
+			return application.Id, application.CreatedAt.Format(DateTimeLayout)
+		}
+		return application.Id, ""
+	}
+}
+
+var applicationStatuses = []structures.Status{
+	structures.StatusPending,
+	structures.StatusProcessing,
+	structures.StatusApproved,
+	structures.StatusExecuting,
+	structures.StatusCompleted,
+	structures.StatusRejected,
+	structures.StatusCancelled,
+}
+
+// ApplicationQuery — фильтр списка заявок в том виде, в каком его прислал
+// клиент. From и To принимаются в том же виде, что и start_date.
+type ApplicationQuery struct {
+	Statuses []string
+	DroneId  int
+	From     string
+	To       string
+}
+
+// ParseApplicationFilter проверяет статусы, drone_id и интервал from/to по
+// дате начала.
+func ParseApplicationFilter(query ApplicationQuery) (repository.ApplicationFilter, error) {
+	var filter repository.ApplicationFilter
+	var fields []apperror.FieldError
+
+	for _, status := range query.Statuses {
+		status := structures.Status(strings.TrimSpace(status))
+		if !slices.Contains(applicationStatuses, status) {
+			fields = append(fields, apperror.FieldError{Field: "query.status", Message: "unknown status " + string(status)})
+			continue
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if query.DroneId < 0 {
+		fields = append(fields, apperror.FieldError{Field: "query.drone_id", Message: "must be a positive integer"})
+	}
+	filter.DroneId = query.DroneId
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		field *string
+	}{{"from", query.From, &filter.From}, {"to", query.To, &filter.To}} {
+		if bound.value == "" {
+			continue
+		}
+
+		parsed, err := ParseLocalDateTime(bound.value)
+		if err != nil {
+			fields = append(fields, apperror.FieldError{Field: "query." + bound.name, Message: "must be a date-time like 2025-05-01T10:00"})
+			continue
+		}
+		*bound.field = parsed.Format(DateTimeLayout)
+	}
+
+	if len(fields) > 0 {
+		return filter, apperror.Validation("Invalid filter", fields...)
+	}
+
+	return filter, nil
+}
+
+// ParseLocalDateTime разбирает дату заявки без часового пояса.
+func ParseLocalDateTime(value string) (time.Time, error) {
+	value = strings.Replace(value, "T", " ", 1)
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid date-time")
+}
+
+// ParseBoundingBox разбирает bbox в формате minLat,minLon,maxLat,maxLon,
+// как в подписках /events.
+func ParseBoundingBox(value string) (*repository.BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("must be minLat,minLon,maxLat,maxLon")
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errors.New("must contain four numbers")
+		}
+		values[i] = parsed
+	}
+
+	return ValidateBoundingBox(repository.BoundingBox{
+		MinLatitude:  values[0],
+		MinLongitude: values[1],
+		MaxLatitude:  values[2],
+		MaxLongitude: values[3],
+	})
+}
+
+func ValidateBoundingBox(box repository.BoundingBox) (*repository.BoundingBox, error) {
+	if box.MinLatitude > box.MaxLatitude || box.MinLongitude > box.MaxLongitude {
+		return nil, errors.New("minimum must not exceed maximum")
+	}
+
+	return &box, nil
+}
@@ -0,0 +1,28 @@
+package openapi
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+)
+
+// Validator проверяет запрос по операции, описанной для его маршрута.
+// Ставится в цепочку конкретного маршрута, а не через Use: только там
+// c.Route() указывает на зарегистрированный путь с параметрами.
+func (s *Spec) Validator() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route := c.Route()
+
+		operation := s.Operation(route.Method, route.Path)
+		if operation == nil {
+			log.Warnf("Route %s %s is not described in the OpenAPI document", route.Method, route.Path)
+			return c.Next()
+		}
+
+		if fields := operation.Validate(c); len(fields) > 0 {
+			return apperror.Validation("Validation failed", fields...)
+		}
+
+		return c.Next()
+	}
+}
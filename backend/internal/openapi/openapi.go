@@ -0,0 +1,220 @@
+// Package openapi проверяет входящие HTTP-запросы по документу api/openapi.json.
+// Поддерживается только то подмножество OpenAPI 3, которое используется в
+// документе: параметры path и query, JSON-тело и локальные $ref.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Document struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+}
+
+type Operation struct {
+	OperationId string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Pattern    string             `json:"pattern"`
+	Enum       []interface{}      `json:"enum"`
+	Nullable   bool               `json:"nullable"`
+	Required   []string           `json:"required"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	MinLength  *int               `json:"minLength"`
+	MaxLength  *int               `json:"maxLength"`
+
+	ExclusiveMinimum bool `json:"exclusiveMinimum"`
+
+	pattern *regexp.Regexp
+}
+
+// Spec — разобранный документ с операциями, проиндексированными по методу и пути.
+type Spec struct {
+	operations map[string]*Operation
+}
+
+// Load разбирает документ и заранее разрешает все $ref, чтобы ошибки в
+// документе обнаруживались при старте, а не на первом запросе.
+func Load(data []byte) (*Spec, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+
+	r := resolver{components: doc.Components, resolved: map[*Schema]bool{}}
+	spec := &Spec{operations: map[string]*Operation{}}
+
+	for path, methods := range doc.Paths {
+		for method, operation := range methods {
+			for i, parameter := range operation.Parameters {
+				resolvedParameter, err := r.parameter(parameter)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+				operation.Parameters[i] = resolvedParameter
+			}
+
+			if operation.RequestBody != nil {
+				for contentType, media := range operation.RequestBody.Content {
+					schema, err := r.schema(media.Schema)
+					if err != nil {
+						return nil, fmt.Errorf("%s %s: %w", method, path, err)
+					}
+					operation.RequestBody.Content[contentType] = MediaType{Schema: schema}
+				}
+			}
+
+			spec.operations[operationKey(method, path)] = operation
+		}
+	}
+
+	return spec, nil
+}
+
+// MustLoad как Load, но падает на некорректном документе.
+func MustLoad(data []byte) *Spec {
+	spec, err := Load(data)
+	if err != nil {
+		panic(err)
+	}
+
+	return spec
+}
+
+// Operation ищет операцию по методу и пути маршрута Fiber (/drone/:id).
+func (s *Spec) Operation(method, routePath string) *Operation {
+	if method == "HEAD" {
+		method = "GET"
+	}
+
+	return s.operations[operationKey(method, templatePath(routePath))]
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// templatePath переводит путь Fiber в шаблон OpenAPI: /drone/:id -> /drone/{id}.
+func templatePath(routePath string) string {
+	if len(routePath) > 1 {
+		routePath = strings.TrimSuffix(routePath, "/")
+	}
+
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "?") + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+type resolver struct {
+	components Components
+	resolved   map[*Schema]bool
+}
+
+func (r resolver) parameter(parameter *Parameter) (*Parameter, error) {
+	if parameter.Ref != "" {
+		name := strings.TrimPrefix(parameter.Ref, "#/components/parameters/")
+		component, ok := r.components.Parameters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q", parameter.Ref)
+		}
+		parameter = component
+	}
+
+	if parameter.In != "path" && parameter.In != "query" {
+		return nil, fmt.Errorf("parameter %q: unsupported location %q", parameter.Name, parameter.In)
+	}
+
+	schema, err := r.schema(parameter.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", parameter.Name, err)
+	}
+	parameter.Schema = schema
+
+	return parameter, nil
+}
+
+func (r resolver) schema(schema *Schema) (*Schema, error) {
+	if schema == nil {
+		return &Schema{}, nil
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		component, ok := r.components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %q", schema.Ref)
+		}
+		schema = component
+	}
+
+	if r.resolved[schema] {
+		return schema, nil
+	}
+	r.resolved[schema] = true
+
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+		schema.pattern = pattern
+	}
+
+	for name, property := range schema.Properties {
+		resolvedProperty, err := r.schema(property)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		schema.Properties[name] = resolvedProperty
+	}
+
+	if schema.Items != nil {
+		items, err := r.schema(schema.Items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		schema.Items = items
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+)
+
+// Validate проверяет параметры и тело запроса. Пустой результат — запрос
+// соответствует операции.
+func (o *Operation) Validate(c *fiber.Ctx) []apperror.FieldError {
+	var errs []apperror.FieldError
+
+	for _, parameter := range o.Parameters {
+		field := parameter.In + "." + parameter.Name
+
+		var value string
+		var present bool
+		switch parameter.In {
+		case "path":
+			value = c.Params(parameter.Name)
+			present = value != ""
+		case "query":
+			value = c.Query(parameter.Name)
+			present = c.Context().QueryArgs().Has(parameter.Name)
+		}
+
+		if !present {
+			if parameter.Required {
+				errs = append(errs, apperror.FieldError{Field: field, Message: "is required"})
+			}
+			continue
+		}
+
+		parsed, err := parseParameter(value, parameter.Schema)
+		if err != nil {
+			errs = append(errs, apperror.FieldError{Field: field, Message: err.Error()})
+			continue
+		}
+
+		parameter.Schema.validate(parsed, field, &errs)
+	}
+
+	if o.RequestBody != nil {
+		errs = append(errs, o.validateBody(c)...)
+	}
+
+	return errs
+}
+
+func (o *Operation) validateBody(c *fiber.Ctx) []apperror.FieldError {
+	media, ok := o.RequestBody.Content[fiber.MIMEApplicationJSON]
+	if !ok {
+		return nil
+	}
+
+	body := bytes.TrimSpace(c.Body())
+	if len(body) == 0 {
+		if o.RequestBody.Required {
+			return []apperror.FieldError{{Field: "body", Message: "is required"}}
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		return []apperror.FieldError{{Field: "body", Message: "must be sent as " + fiber.MIMEApplicationJSON}}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []apperror.FieldError{{Field: "body", Message: "must be valid JSON"}}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return []apperror.FieldError{{Field: "body", Message: "must contain a single JSON value"}}
+	}
+
+	var errs []apperror.FieldError
+	media.Schema.validate(value, "body", &errs)
+
+	return errs
+}
+
+var typeNames = map[string]string{
+	"integer": "an integer",
+	"number":  "a number",
+}
+
+// parseParameter приводит строку из path или query к типу схемы, чтобы
+// дальше проверять ее так же, как значение из JSON.
+func parseParameter(value string, schema *Schema) (interface{}, error) {
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("must be %s", typeNames[schema.Type])
+		}
+		return json.Number(value), nil
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return parsed, nil
+	default:
+		return value, nil
+	}
+}
+
+func (s *Schema) validate(value interface{}, field string, errs *[]apperror.FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, apperror.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if value == nil {
+		if !s.Nullable && s.Type != "" {
+			fail("must not be null")
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				*errs = append(*errs, apperror.FieldError{Field: field + "." + name, Message: "is required"})
+			}
+		}
+
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if propertyValue, ok := object[name]; ok {
+				s.Properties[name].validate(propertyValue, field+"."+name, errs)
+			}
+		}
+
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", field, i), errs)
+			}
+		}
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+
+		length := utf8.RuneCountInString(text)
+		if s.MinLength != nil && length < *s.MinLength {
+			if *s.MinLength == 1 {
+				fail("must not be empty")
+			} else {
+				fail("must be at least %d characters", *s.MinLength)
+			}
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(text) {
+			fail("must match %s", s.Pattern)
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				fail("must be an RFC3339 date-time")
+			}
+		}
+
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			fail("must be %s", typeNames[s.Type])
+			return
+		}
+
+		parsed, err := number.Float64()
+		if err != nil || (s.Type == "integer" && parsed != math.Trunc(parsed)) {
+			fail("must be %s", typeNames[s.Type])
+			return
+		}
+
+		if s.Minimum != nil && s.ExclusiveMinimum && parsed <= *s.Minimum {
+			fail("must be > %v", *s.Minimum)
+		} else if s.Minimum != nil && parsed < *s.Minimum {
+			fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && parsed > *s.Maximum {
+			fail("must be <= %v", *s.Maximum)
+		}
+		value = parsed
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !s.allows(value) {
+		allowed := make([]string, 0, len(s.Enum))
+		for _, option := range s.Enum {
+			allowed = append(allowed, fmt.Sprint(option))
+		}
+		fail("must be one of: %s", strings.Join(allowed, ", "))
+	}
+}
+
+// allows сравнивает значение с enum. Числа из документа приходят как float64,
+// поэтому и значение к этому моменту уже приведено к float64.
+func (s *Schema) allows(value interface{}) bool {
+	for _, option := range s.Enum {
+		if option == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,227 @@
+// Package recurrence разворачивает правила повторения в духе RRULE (RFC 5545)
+// в конкретные даты. Поддерживается подмножество, нужное для регулярных
+// полетов: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, COUNT, UNTIL, BYDAY без
+// порядковых номеров и BYMONTHDAY. Правило обязано быть конечным.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// ErrTooManyOccurrences — правило дает больше дат, чем разрешено.
+var ErrTooManyOccurrences = errors.New("rule produces too many occurrences")
+
+// searchHorizon ограничивает перебор дней, если правило почти ничего не
+// выбирает, например BYMONTHDAY=31 с INTERVAL=2.
+const searchHorizon = 10 * 366
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+// Parse разбирает строку вида FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=20,
+// допускается префикс RRULE:. UNTIL трактуется как местное время, как и
+// даты заявок.
+func Parse(value string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return rule, errors.New("rule is empty")
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, arg, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !ok || arg == "" {
+			return rule, fmt.Errorf("invalid part %q", part)
+		}
+		if seen[name] {
+			return rule, fmt.Errorf("%s is set twice", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(arg))
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				return rule, fmt.Errorf("unsupported FREQ %q", arg)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(arg)
+			if err != nil || interval < 1 {
+				return rule, fmt.Errorf("INTERVAL must be a positive integer")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(arg)
+			if err != nil || count < 1 {
+				return rule, fmt.Errorf("COUNT must be a positive integer")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(arg)
+			if err != nil {
+				return rule, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(arg), ",") {
+				weekday, ok := weekdays[strings.TrimSpace(day)]
+				if !ok {
+					return rule, fmt.Errorf("unsupported BYDAY value %q", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(arg, ",") {
+				monthDay, err := strconv.Atoi(strings.TrimSpace(day))
+				if err != nil || monthDay < 1 || monthDay > 31 {
+					return rule, fmt.Errorf("BYMONTHDAY values must be between 1 and 31")
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		default:
+			return rule, fmt.Errorf("unsupported part %s", name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return rule, errors.New("FREQ is required")
+	}
+	if rule.Count == 0 && rule.Until.IsZero() {
+		return rule, errors.New("COUNT or UNTIL is required")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return rule, errors.New("COUNT and UNTIL cannot be combined")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly {
+		return rule, errors.New("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	value = strings.TrimSuffix(strings.ToUpper(value), "Z")
+
+	if until, err := time.Parse("20060102T150405", value); err == nil {
+		return until, nil
+	}
+
+	// Дата без времени включает весь день
+	if until, err := time.Parse("20060102", value); err == nil {
+		return until.Add(24*time.Hour - time.Second), nil
+	}
+
+	return time.Time{}, fmt.Errorf("UNTIL must look like 20250131 or 20250131T235959")
+}
+
+// Occurrences возвращает даты начала по порядку, первая — start, если она
+// подходит под правило. Время суток у всех дат берется из start. Если дат
+// больше max, возвращает ErrTooManyOccurrences.
+func (r Rule) Occurrences(start time.Time, max int) ([]time.Time, error) {
+	var result []time.Time
+
+	startDay := dayOf(start)
+	clock := start.Sub(startDay)
+
+	for i := 0; i < searchHorizon; i++ {
+		day := startDay.AddDate(0, 0, i)
+		occurrence := day.Add(clock)
+
+		if !r.Until.IsZero() && occurrence.After(r.Until) {
+			break
+		}
+
+		if !r.matches(startDay, day) {
+			continue
+		}
+
+		if len(result) == max {
+			return nil, ErrTooManyOccurrences
+		}
+		result = append(result, occurrence)
+
+		if r.Count > 0 && len(result) == r.Count {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (r Rule) matches(startDay, day time.Time) bool {
+	switch r.Freq {
+	case Daily:
+		days := int(day.Sub(startDay).Hours()/24 + 0.5)
+		if days%r.Interval != 0 {
+			return false
+		}
+		return len(r.ByDay) == 0 || slices.Contains(r.ByDay, day.Weekday())
+
+	case Weekly:
+		weeks := int(weekStart(day).Sub(weekStart(startDay)).Hours()/(24*7) + 0.5)
+		if weeks%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == startDay.Weekday()
+		}
+		return slices.Contains(r.ByDay, day.Weekday())
+
+	case Monthly:
+		months := (day.Year()-startDay.Year())*12 + int(day.Month()) - int(startDay.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, day.Weekday()) {
+			return false
+		}
+		if len(r.ByMonthDay) > 0 {
+			return slices.Contains(r.ByMonthDay, day.Day())
+		}
+		return len(r.ByDay) > 0 || day.Day() == startDay.Day()
+	}
+
+	return false
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weekStart возвращает понедельник недели, неделя начинается с MO (WKST=MO).
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,165 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"unsupported frequency", "FREQ=YEARLY;COUNT=1"},
+		{"unbounded", "FREQ=DAILY"},
+		{"count with until", "FREQ=DAILY;COUNT=2;UNTIL=20250101"},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0;COUNT=1"},
+		{"repeated part", "FREQ=DAILY;COUNT=1;COUNT=2"},
+		{"month day out of range", "FREQ=MONTHLY;BYMONTHDAY=32;COUNT=1"},
+		{"month day with weekly", "FREQ=WEEKLY;BYMONTHDAY=1;COUNT=1"},
+		{"ordinal weekday", "FREQ=MONTHLY;BYDAY=1MO;COUNT=1"},
+		{"malformed until", "FREQ=DAILY;UNTIL=2025-01-31"},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(tt.rule); err == nil {
+			t.Errorf("%s: Parse(%q) succeeded, want error", tt.name, tt.rule)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "daily interval across month boundary",
+			rule:  "FREQ=DAILY;INTERVAL=3;COUNT=4",
+			start: date(2025, time.January, 30, 10, 0),
+			want: []time.Time{
+				date(2025, time.January, 30, 10, 0),
+				date(2025, time.February, 2, 10, 0),
+				date(2025, time.February, 5, 10, 0),
+				date(2025, time.February, 8, 10, 0),
+			},
+		},
+		{
+			name:  "weekly interval across year boundary",
+			rule:  "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=5",
+			start: date(2024, time.December, 27, 9, 0),
+			want: []time.Time{
+				date(2024, time.December, 27, 9, 0),
+				date(2025, time.January, 6, 9, 0),
+				date(2025, time.January, 10, 9, 0),
+				date(2025, time.January, 20, 9, 0),
+				date(2025, time.January, 24, 9, 0),
+			},
+		},
+		{
+			name:  "weekly without BYDAY keeps the start weekday",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: date(2025, time.January, 29, 7, 0),
+			want: []time.Time{
+				date(2025, time.January, 29, 7, 0),
+				date(2025, time.February, 5, 7, 0),
+				date(2025, time.February, 12, 7, 0),
+			},
+		},
+		{
+			name:  "monthly interval across year boundary",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;COUNT=3",
+			start: date(2025, time.November, 15, 12, 0),
+			want: []time.Time{
+				date(2025, time.November, 15, 12, 0),
+				date(2026, time.January, 15, 12, 0),
+				date(2026, time.March, 15, 12, 0),
+			},
+		},
+		{
+			name:  "until as a date includes the whole day",
+			rule:  "FREQ=DAILY;UNTIL=20250105",
+			start: date(2025, time.January, 3, 18, 30),
+			want: []time.Time{
+				date(2025, time.January, 3, 18, 30),
+				date(2025, time.January, 4, 18, 30),
+				date(2025, time.January, 5, 18, 30),
+			},
+		},
+		{
+			name:  "until with time cuts the last day",
+			rule:  "FREQ=DAILY;UNTIL=20250105T120000Z",
+			start: date(2025, time.January, 3, 18, 30),
+			want: []time.Time{
+				date(2025, time.January, 3, 18, 30),
+				date(2025, time.January, 4, 18, 30),
+			},
+		},
+		{
+			name:  "month day 31 skips shorter months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=4",
+			start: date(2025, time.January, 31, 8, 0),
+			want: []time.Time{
+				date(2025, time.January, 31, 8, 0),
+				date(2025, time.March, 31, 8, 0),
+				date(2025, time.May, 31, 8, 0),
+				date(2025, time.July, 31, 8, 0),
+			},
+		},
+		{
+			name:  "month day 31 with interval waits for a long month",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=31;COUNT=2",
+			start: date(2025, time.February, 10, 8, 0),
+			want: []time.Time{
+				date(2025, time.August, 31, 8, 0),
+				date(2025, time.October, 31, 8, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("%s: Parse(%q): %v", tt.name, tt.rule, err)
+		}
+
+		got, err := rule.Occurrences(tt.start, 100)
+		if err != nil {
+			t.Fatalf("%s: Occurrences: %v", tt.name, err)
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d occurrences %v, want %d %v", tt.name, len(got), got, len(tt.want), tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: occurrence %d = %v, want %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestOccurrencesLimit(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;COUNT=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rule.Occurrences(date(2025, time.January, 1, 0, 0), 5); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("Occurrences over the limit: got %v, want ErrTooManyOccurrences", err)
+	}
+
+	got, err := rule.Occurrences(date(2025, time.January, 1, 0, 0), 10)
+	if err != nil || len(got) != 10 {
+		t.Errorf("Occurrences at the limit: got %d, %v; want 10 occurrences", len(got), err)
+	}
+}
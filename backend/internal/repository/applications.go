@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2/log"
@@ -12,6 +14,16 @@ type ApplicationRepository struct {
 	DB *sql.DB
 }
 
+// ApplicationFilter — условия списка заявок пилота. From и To ограничивают
+// start_date и задаются в том же формате, в котором дата хранится.
+type ApplicationFilter struct {
+	PilotId  int
+	Statuses []structures.Status
+	DroneId  int
+	From     string
+	To       string
+}
+
 func (a *ApplicationRepository) CreateApplication(req structures.CreateApplicationRequest) error {
 	tx, err := a.DB.Begin()
 	if err != nil {
@@ -33,10 +45,16 @@ func (a *ApplicationRepository) CreateApplication(req structures.CreateApplicati
 		return err
 	}
 
+	actions, err := routeActions(req.Actions)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	_, err = tx.Exec(`
-		INSERT INTO Route (latitude, longtitude, altitude, point_order, application_id)
-		VALUES (?, ?, ?, ?, ?)`,
-		req.Latitude, req.Longtitude, req.Altitude, req.PointOrder, applicationID)
+		INSERT INTO Route (latitude, longtitude, altitude, point_order, application_id, actions)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		req.Latitude, req.Longitude, req.Altitude, req.PointOrder, applicationID, actions)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -45,41 +63,140 @@ func (a *ApplicationRepository) CreateApplication(req structures.CreateApplicati
 	return tx.Commit()
 }
 
+func (a *ApplicationRepository) SelectApplication(id int) (*structures.Application, error) {
+	var application structures.Application
+
+	var createdAtBytes, lastUpdateBytes []byte
+	err := a.DB.QueryRow(`SELECT application_id, start_date, end_date, status, COALESCE(rejection_reason, ''),
+							COALESCE(restricted_zone_check, 0), created_at, COALESCE(last_update, created_at), pilot_id, drone_id, revision,
+							COALESCE(series_id, 0), mission_type
+							FROM Application
+							WHERE application_id = ?`, id).Scan(
+		&application.Id, &application.Start_date, &application.End_date, &application.Status, &application.Rejection_reason,
+		&application.Restricted_zone_check, &createdAtBytes, &lastUpdateBytes, &application.Pilot_id, &application.Drone_id,
+		&application.Revision, &application.SeriesId, &application.MissionType)
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	application.Created_at, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
+	if err != nil {
+		log.Error("invalid datetime format from DB:", err)
+		return nil, err
+	}
+
+	application.Last_update, err = time.Parse(telemetryTimeLayout, string(lastUpdateBytes))
+	if err != nil {
+		log.Error("invalid datetime format from DB:", err)
+		return nil, err
+	}
+
+	return &application, nil
+}
+
+// DeleteApplication удаляет заявку вместе с маршрутом, историей изменений и
+// параметрами съемки.
+// Заявку, которую процессор проверяет или выполняет, удалить нельзя — ее
+// нужно отменить.
 func (a *ApplicationRepository) DeleteApplication(id int) error {
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	res, err := tx.Exec("DELETE FROM Application WHERE application_id = ?", id)
+	var status structures.Status
+	err = tx.QueryRow("SELECT status FROM Application WHERE application_id = ? FOR UPDATE", id).Scan(&status)
 	if err != nil {
-		tx.Rollback()
-		return err
+		return translate(err)
 	}
 
-	applicationId, err := res.LastInsertId()
-	if err != nil {
-		tx.Rollback()
-		return err
+	if status == structures.StatusProcessing || status == structures.StatusExecuting {
+		return ErrInvalidState
 	}
 
-	_, err = tx.Exec("DELETE FROM Route WHERE application_id = ?", applicationId)
+	// Команда отмены, которую процессор еще не выполнил, значит, что дрон
+	// может быть в воздухе, даже если статус уже не executing
+	var pendingCommands int
+	err = tx.QueryRow("SELECT COUNT(*) FROM flight_commands WHERE application_id = ? AND processed_at IS NULL", id).Scan(&pendingCommands)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
+	if pendingCommands > 0 {
+		return ErrInvalidState
+	}
+
+	for _, query := range []string{
+		"DELETE FROM Route WHERE application_id = ?",
+		"DELETE FROM application_revisions WHERE application_id = ?",
+		"DELETE FROM survey_missions WHERE application_id = ?",
+		"DELETE FROM Application WHERE application_id = ?",
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
 
 	return tx.Commit()
 }
 
-func (a *ApplicationRepository) AllPilotsAplications(id int) ([]structures.AllPitlotsApl, error) {
-	var applications []structures.AllPitlotsApl
+// applicationSorts — допустимые ключи сортировки списка заявок.
+var applicationSorts = map[string]string{
+	"id":         "",
+	"start_date": "a.start_date",
+	"created_at": "a.created_at",
+}
+
+func (a *ApplicationRepository) AllPilotsAplications(filter ApplicationFilter, page Page) ([]structures.AllPitlotsApl, error) {
+	applications := []structures.AllPitlotsApl{}
+
+	conditions := []string{"a.pilot_id = ?"}
+	args := []interface{}{filter.PilotId}
 
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "a.status IN (?"+strings.Repeat(", ?", len(filter.Statuses)-1)+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.DroneId != 0 {
+		conditions = append(conditions, "a.drone_id = ?")
+		args = append(args, filter.DroneId)
+	}
+	if filter.From != "" {
+		conditions = append(conditions, "a.start_date >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		conditions = append(conditions, "a.start_date <= ?")
+		args = append(args, filter.To)
+	}
+
+	if page.Sort == "" {
+		page.Sort = "id"
+	}
+
+	column, ok := applicationSorts[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown application sort %q", page.Sort)
+	}
+
+	after, afterArgs, order := page.clauses(column, "a.application_id")
+	if after != "" {
+		conditions = append(conditions, after)
+		args = append(args, afterArgs...)
+	}
+
+	// У заявки может быть несколько точек маршрута, в списке показывается первая.
 	rows, err := a.DB.Query(`SELECT a.application_id, a.start_date, a.status, a.created_at, d.serial_number, r.latitude, r.longtitude, r.altitude
-							FROM Application a 
+							FROM Application a
 							JOIN Drone d ON a.drone_id=d.drone_id
-							JOIN Route r ON r.application_id=a.application_id
-							WHERE a.pilot_id = ?`, id)
+							JOIN Route r ON r.route_id = (
+								SELECT r2.route_id FROM Route r2
+								WHERE r2.application_id = a.application_id
+								ORDER BY r2.point_order, r2.route_id
+								LIMIT 1)
+							`+whereClause(conditions)+" "+order, args...)
 	if err != nil {
 		log.Error(err)
 		return applications, err
@@ -87,31 +204,25 @@ func (a *ApplicationRepository) AllPilotsAplications(id int) ([]structures.AllPi
 
 	defer rows.Close()
 
-	applicationMap := make(map[int]*structures.AllPitlotsApl)
-
 	for rows.Next() {
 		var application structures.AllPitlotsApl
 
 		var createdAtBytes []byte
 		err := rows.Scan(&application.Id, &application.StartDate, &application.Status,
-			&createdAtBytes, &application.Serialnumber, &application.Latitude, &application.Longtitude, &application.Altitude)
+			&createdAtBytes, &application.Serialnumber, &application.Latitude, &application.Longitude, &application.Altitude)
 		if err != nil {
 			log.Error(err)
-			return applications, nil
+			return applications, err
 		}
 
-		application.CreatedAt, err = time.Parse("2006-01-02 15:04:05", string(createdAtBytes))
+		application.CreatedAt, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
 		if err != nil {
 			log.Error("invalid datetime format from DB:", err)
-			return applications, nil
+			return applications, err
 		}
 
-		applicationMap[application.Id] = &application
-	}
-
-	for _, application := range applicationMap {
-		applications = append(applications, *application)
+		applications = append(applications, application)
 	}
 
-	return applications, nil
+	return applications, rows.Err()
 }
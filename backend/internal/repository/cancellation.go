@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// CancelApplication отменяет заявку, сохраняя ее в истории. Заявку, которая
+// еще не летит, отменяет сразу и увеличивает ревизию, чтобы процессор не
+// перезаписал статус результатом своей проверки. Для выполняемого полета
+// ставит команду процессору; статус cancelled выставит он, когда прервет
+// полет или когда дрон вернется в точку взлета. Возвращает статус заявки
+// после вызова.
+func (a *ApplicationRepository) CancelApplication(id, pilotId int, command structures.FlightCommand, reason string) (structures.Status, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var status structures.Status
+	err = tx.QueryRow("SELECT status FROM Application WHERE application_id = ? FOR UPDATE", id).Scan(&status)
+	if err != nil {
+		return "", translate(err)
+	}
+
+	switch status {
+	case structures.StatusPending, structures.StatusProcessing, structures.StatusApproved:
+		_, err = tx.Exec(`UPDATE Application
+							SET status = ?, rejection_reason = ?, last_update = ?, revision = revision + 1
+							WHERE application_id = ?`,
+			structures.StatusCancelled, reason, time.Now(), id)
+		if err != nil {
+			log.Error(err)
+			return "", err
+		}
+		status = structures.StatusCancelled
+
+	case structures.StatusExecuting:
+		_, err = tx.Exec(`INSERT INTO flight_commands (application_id, command, reason, requested_by)
+							VALUES (?, ?, ?, ?)`,
+			id, command, reason, pilotId)
+		if err != nil {
+			log.Error(err)
+			return "", err
+		}
+
+	default:
+		return "", ErrInvalidState
+	}
+
+	return status, tx.Commit()
+}
@@ -34,7 +34,7 @@ func (d *DroneRepository) InsertDrone(drone *structures.Drone) error {
 
 	_, err = d.DB.Exec("INSERT INTO Drone (serial_number, model_id) VALUES (?, ?)", drone.Serial_number, modelID)
 	if err != nil {
-		return err
+		return translate(err)
 	}
 
 	return nil
@@ -45,7 +45,7 @@ func (d *DroneRepository) SelectDroneById(id int) (*structures.Drone, error) {
 	err := d.DB.QueryRow("SELECT m.model_name, d.serial_number, b.brand_name FROM Drone d JOIN Model m ON m.model_id=d.model_id JOIN Brand b ON m.brand_id=b.brand_id WHERE d.drone_id = ?", id).Scan(&drone.Model_name, &drone.Serial_number, &drone.Brand_name)
 	if err != nil {
 		log.Error(err)
-		return drone, err
+		return drone, translate(err)
 	}
 
 	drone.Id = id
@@ -53,10 +53,19 @@ func (d *DroneRepository) SelectDroneById(id int) (*structures.Drone, error) {
 	return drone, nil
 }
 
-func (d *DroneRepository) SelectAllDrones() ([]structures.Drone, error) {
-	var drones []structures.Drone
+func (d *DroneRepository) SelectAllDrones(page Page) ([]structures.Drone, error) {
+	drones := []structures.Drone{}
+
+	where, args, order := page.clauses("", "d.drone_id")
+	if where != "" {
+		where = "WHERE " + where
+	}
 
-	rows, err := d.DB.Query("SELECT d.drone_id, d.serial_number, m.model_name, b.brand_name FROM Drone d JOIN Model m ON m.model_id=d.model_id JOIN Brand b ON m.brand_id=b.brand_id")
+	rows, err := d.DB.Query(`SELECT d.drone_id, d.serial_number, m.model_name, b.brand_name
+							FROM Drone d
+							JOIN Model m ON m.model_id=d.model_id
+							JOIN Brand b ON m.brand_id=b.brand_id
+							`+where+" "+order, args...)
 	if err != nil {
 		log.Error(err)
 		return nil, err
@@ -64,8 +73,6 @@ func (d *DroneRepository) SelectAllDrones() ([]structures.Drone, error) {
 
 	defer rows.Close()
 
-	dronesMap := make(map[int]*structures.Drone)
-
 	for rows.Next() {
 		var drone structures.Drone
 
@@ -75,14 +82,10 @@ func (d *DroneRepository) SelectAllDrones() ([]structures.Drone, error) {
 			return nil, err
 		}
 
-		dronesMap[drone.Id] = &drone
+		drones = append(drones, drone)
 	}
 
-	for _, drone := range dronesMap {
-		drones = append(drones, *drone)
-	}
-
-	return drones, nil
+	return drones, rows.Err()
 }
 
 func (d *DroneRepository) DeleteDrone(droneID int) error {
@@ -95,7 +98,7 @@ func (d *DroneRepository) DeleteDrone(droneID int) error {
 	err = tx.QueryRow("SELECT model_id FROM Drone WHERE drone_id = ?", droneID).Scan(&modelID)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return translate(err)
 	}
 
 	_, err = tx.Exec("DELETE FROM Drone WHERE drone_id = ?", droneID)
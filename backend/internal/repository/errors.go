@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var (
+	// ErrNotFound — запрошенной записи нет.
+	ErrNotFound = errors.New("record not found")
+	// ErrConflict — запись нарушает уникальный ключ.
+	ErrConflict = errors.New("record already exists")
+	// ErrInvalidState — запись в состоянии, которое не допускает изменения.
+	ErrInvalidState = errors.New("record state does not allow the change")
+	// ErrSurveyMission — маршрут заявки на съемку построен по многоугольнику
+	// и не заменяется одной точкой.
+	ErrSurveyMission = errors.New("survey mission route cannot be amended")
+)
+
+const mysqlDuplicateEntry = 1062
+
+// translate подменяет ошибки драйвера на ошибки пакета, чтобы вызывающий
+// код не зависел от database/sql и MySQL.
+func translate(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return ErrConflict
+	}
+
+	return err
+}
+
+// requireAffected возвращает ErrNotFound, если запрос не затронул ни одной строки.
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func (n *NotificationRepository) InsertNotificationEvent(event *structures.NotificationEvent) error {
+	var latitude, longitude sql.NullFloat64
+	if event.HasPosition {
+		latitude = sql.NullFloat64{Float64: event.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: event.Longitude, Valid: true}
+	}
+
+	event.CreatedAt = time.Now()
+
+	res, err := n.DB.Exec(`INSERT INTO notification_events
+							(event_type, application_id, drone_id, pilot_id, latitude, longitude, payload, created_at)
+							VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.EventType, event.ApplicationId, event.DroneId, event.PilotId,
+		latitude, longitude, event.Payload, event.CreatedAt)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	event.Id, err = res.LastInsertId()
+	return err
+}
+
+func (n *NotificationRepository) SelectNotificationEventsAfter(afterId int64, limit int) ([]structures.NotificationEvent, error) {
+	events := []structures.NotificationEvent{}
+
+	rows, err := n.DB.Query(`SELECT event_id, event_type, application_id, drone_id, pilot_id, latitude, longitude, payload, created_at
+							FROM notification_events
+							WHERE event_id > ?
+							ORDER BY event_id ASC
+							LIMIT ?`, afterId, limit)
+	if err != nil {
+		log.Error(err)
+		return events, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var event structures.NotificationEvent
+
+		var latitude, longitude sql.NullFloat64
+		var createdAtBytes []byte
+		err := rows.Scan(&event.Id, &event.EventType, &event.ApplicationId, &event.DroneId, &event.PilotId,
+			&latitude, &longitude, &event.Payload, &createdAtBytes)
+		if err != nil {
+			log.Error(err)
+			return events, err
+		}
+
+		event.Latitude = latitude.Float64
+		event.Longitude = longitude.Float64
+		event.HasPosition = latitude.Valid && longitude.Valid
+
+		event.CreatedAt, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
+		if err != nil {
+			log.Error("invalid datetime format from DB:", err)
+			return events, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteNotificationEventsBefore удаляет из журнала события старше before.
+// Клиент, вернувшийся позже, получит поток без пропущенных событий.
+func (n *NotificationRepository) DeleteNotificationEventsBefore(before time.Time) (int64, error) {
+	res, err := n.DB.Exec(`DELETE FROM notification_events WHERE created_at < ?`, before)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// DeleteProcessedNotificationsBefore забывает принятые уведомления старше before.
+func (n *NotificationRepository) DeleteProcessedNotificationsBefore(before time.Time) (int64, error) {
+	res, err := n.DB.Exec(`DELETE FROM processed_notifications WHERE created_at < ?`, before)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (n *NotificationRepository) SelectProcessedNotification(eventId string) (bool, error) {
+	var exists int
+	err := n.DB.QueryRow(`SELECT 1 FROM processed_notifications WHERE event_id = ?`, eventId).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (n *NotificationRepository) InsertProcessedNotification(notification *structures.ProcessedNotification) error {
+	notification.CreatedAt = time.Now()
+
+	_, err := n.DB.Exec(`INSERT IGNORE INTO processed_notifications
+							(event_id, event_type, application_id, created_at)
+							VALUES (?, ?, ?, ?)`,
+		notification.EventId, notification.EventType, notification.ApplicationId, notification.CreatedAt)
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Page — параметры keyset-пагинации. Записи всегда упорядочены по ключу
+// сортировки и затем по id, поэтому порядок стабилен между запросами, а
+// After* указывают на последнюю запись предыдущей страницы.
+type Page struct {
+	Limit      int
+	Sort       string
+	Descending bool
+
+	After      bool
+	AfterId    int
+	AfterValue string
+}
+
+// clauses строит условие продолжения и ORDER BY/LIMIT для колонки column
+// (пусто — сортировка только по idColumn).
+func (p Page) clauses(column, idColumn string) (string, []interface{}, string) {
+	direction, op := "ASC", ">"
+	if p.Descending {
+		direction, op = "DESC", "<"
+	}
+
+	var where string
+	var args []interface{}
+	if p.After {
+		if column == "" {
+			where = fmt.Sprintf("%s %s ?", idColumn, op)
+			args = []interface{}{p.AfterId}
+		} else {
+			where = fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column, op, column, idColumn, op)
+			args = []interface{}{p.AfterValue, p.AfterValue, p.AfterId}
+		}
+	}
+
+	order := fmt.Sprintf("ORDER BY %s %s", idColumn, direction)
+	if column != "" {
+		order = fmt.Sprintf("ORDER BY %s %s, %s %s", column, direction, idColumn, direction)
+	}
+	if p.Limit > 0 {
+		order += fmt.Sprintf(" LIMIT %d", p.Limit)
+	}
+
+	return where, args, order
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
@@ -17,7 +17,7 @@ func (p *PilotRepository) InsertPilot(pilot *structures.Pilot) (int, error) {
 		pilot.Firstname, pilot.Lastname, pilot.Middlename, pilot.Phone, pilot.Password)
 	if err != nil {
 		log.Error(sl.Err(err))
-		return 0, err
+		return 0, translate(err)
 	}
 
 	pilotId, err := result.LastInsertId()
@@ -31,10 +31,10 @@ func (p *PilotRepository) InsertPilot(pilot *structures.Pilot) (int, error) {
 func (p *PilotRepository) SelectPilot(phone string) (*structures.Pilot, error) {
 	pilot := new(structures.Pilot)
 
-	err := p.DB.QueryRow("SELECT pilot_id, firstname, lastname, middlename, password FROM Pilot WHERE phone = ?",
-		phone).Scan(&pilot.Id, &pilot.Firstname, &pilot.Lastname, &pilot.Middlename, &pilot.Password)
+	err := p.DB.QueryRow("SELECT pilot_id, firstname, lastname, middlename, password, role FROM Pilot WHERE phone = ?",
+		phone).Scan(&pilot.Id, &pilot.Firstname, &pilot.Lastname, &pilot.Middlename, &pilot.Password, &pilot.Role)
 	if err != nil {
-		return nil, err
+		return nil, translate(err)
 	}
 
 	return pilot, nil
@@ -43,10 +43,10 @@ func (p *PilotRepository) SelectPilot(phone string) (*structures.Pilot, error) {
 func (p *PilotRepository) SelectPilotById(id int) (*structures.Pilot, error) {
 	pilot := new(structures.Pilot)
 
-	err := p.DB.QueryRow("SELECT firstname, lastname, middlename, phone, password FROM Pilot WHERE pilot_id = ?",
-		id).Scan(&pilot.Firstname, &pilot.Lastname, &pilot.Middlename, &pilot.Phone, &pilot.Password)
+	err := p.DB.QueryRow("SELECT firstname, lastname, middlename, phone, password, role FROM Pilot WHERE pilot_id = ?",
+		id).Scan(&pilot.Firstname, &pilot.Lastname, &pilot.Middlename, &pilot.Phone, &pilot.Password, &pilot.Role)
 	if err != nil {
-		return nil, err
+		return nil, translate(err)
 	}
 
 	return pilot, nil
@@ -11,9 +11,21 @@ type ZonesRepository struct {
 	DB *sql.DB
 }
 
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// ZoneFilter отбирает зоны, центр которых попадает в BoundingBox.
+type ZoneFilter struct {
+	BoundingBox *BoundingBox
+}
+
 func (z *ZonesRepository) InsertZone(zone *structures.RestrictedZone) error {
 	_, err := z.DB.Exec("INSERT INTO Restricted_zones (latitude, longtitude, altitude, name, radius) VALUES (?, ?, ?, ?, ?)",
-		zone.Latitude, zone.Longtitude, zone.Altitude, zone.Name, zone.Radius)
+		zone.Latitude, zone.Longitude, zone.Altitude, zone.Name, zone.Radius)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -22,10 +34,25 @@ func (z *ZonesRepository) InsertZone(zone *structures.RestrictedZone) error {
 	return nil
 }
 
-func (z *ZonesRepository) GetAllZones() ([]structures.RestrictedZone, error) {
-	var zones []structures.RestrictedZone
+func (z *ZonesRepository) GetAllZones(filter ZoneFilter, page Page) ([]structures.RestrictedZone, error) {
+	zones := []structures.RestrictedZone{}
+
+	var conditions []string
+	var args []interface{}
+
+	if box := filter.BoundingBox; box != nil {
+		conditions = append(conditions, "latitude BETWEEN ? AND ?", "longtitude BETWEEN ? AND ?")
+		args = append(args, box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude)
+	}
 
-	rows, err := z.DB.Query("SELECT * FROM Restricted_zones")
+	after, afterArgs, order := page.clauses("", "zone_id")
+	if after != "" {
+		conditions = append(conditions, after)
+		args = append(args, afterArgs...)
+	}
+
+	rows, err := z.DB.Query(`SELECT zone_id, latitude, longtitude, altitude, name, radius
+							FROM Restricted_zones `+whereClause(conditions)+" "+order, args...)
 	if err != nil {
 		log.Error(err)
 		return zones, err
@@ -33,33 +60,27 @@ func (z *ZonesRepository) GetAllZones() ([]structures.RestrictedZone, error) {
 
 	defer rows.Close()
 
-	zonesMap := make(map[int]*structures.RestrictedZone)
-
 	for rows.Next() {
 		var zone structures.RestrictedZone
 
-		err := rows.Scan(&zone.Id, &zone.Latitude, &zone.Longtitude, &zone.Altitude, &zone.Name, &zone.Radius)
+		err := rows.Scan(&zone.Id, &zone.Latitude, &zone.Longitude, &zone.Altitude, &zone.Name, &zone.Radius)
 		if err != nil {
 			log.Error(err)
 			return zones, err
 		}
 
-		zonesMap[zone.Id] = &zone
+		zones = append(zones, zone)
 	}
 
-	for _, zone := range zonesMap {
-		zones = append(zones, *zone)
-	}
-
-	return zones, nil
+	return zones, rows.Err()
 }
 
 func (z *ZonesRepository) DeleteZone(id int) error {
-	_, err := z.DB.Exec("DELETE FROM Restricted_zones WHERE zone_id = ?", id)
+	result, err := z.DB.Exec("DELETE FROM Restricted_zones WHERE zone_id = ?", id)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 
-	return nil
+	return requireAffected(result)
 }
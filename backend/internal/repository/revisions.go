@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// amendableStatuses — статусы, в которых заявку еще можно изменить: она не
+// проверяется процессором прямо сейчас и полет не начат.
+var amendableStatuses = map[structures.Status]bool{
+	structures.StatusPending:  true,
+	structures.StatusApproved: true,
+}
+
+// AmendApplication заменяет время, дрон и маршрут заявки. Прежнее состояние
+// сохраняется в application_revisions, заявка возвращается в pending и
+// получает следующий номер ревизии, по которому процессор отличает
+// устаревшую проверку. Возвращает новый номер ревизии. Заявки на съемку
+// площади не меняются: для них возвращается ErrSurveyMission.
+func (a *ApplicationRepository) AmendApplication(id, pilotId int, req structures.CreateApplicationRequest) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var current structures.ApplicationRevision
+	var rejectionReason sql.NullString
+	var missionType structures.MissionType
+	err = tx.QueryRow(`SELECT revision, start_date, end_date, drone_id, status, rejection_reason, mission_type
+						FROM Application
+						WHERE application_id = ?
+						FOR UPDATE`, id).Scan(
+		&current.Revision, &current.StartDate, &current.EndDate, &current.DroneId, &current.Status, &rejectionReason,
+		&missionType)
+	if err != nil {
+		return 0, translate(err)
+	}
+	current.RejectionReason = rejectionReason.String
+
+	if missionType == structures.MissionSurvey {
+		return 0, ErrSurveyMission
+	}
+
+	if !amendableStatuses[current.Status] {
+		return 0, ErrInvalidState
+	}
+
+	current.Route, err = selectRoute(tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	route, err := json.Marshal(current.Route)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`INSERT INTO application_revisions
+						(application_id, revision, start_date, end_date, drone_id, status, rejection_reason, route, amended_by)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, current.Revision, current.StartDate, current.EndDate, current.DroneId, current.Status, rejectionReason, route, pilotId)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	revision := current.Revision + 1
+
+	_, err = tx.Exec(`UPDATE Application
+						SET start_date = ?, end_date = ?, drone_id = ?, tested = ?, status = ?,
+							rejection_reason = NULL, last_update = ?, revision = ?, mission_type = ?
+						WHERE application_id = ?`,
+		req.StartDate, req.EndDate, req.DroneId, req.Tested, structures.StatusPending, time.Now(), revision,
+		structures.MissionPoint, id)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	if _, err = tx.Exec("DELETE FROM Route WHERE application_id = ?", id); err != nil {
+		return 0, err
+	}
+
+	actions, err := routeActions(req.Actions)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO Route (latitude, longtitude, altitude, point_order, application_id, actions)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		req.Latitude, req.Longitude, req.Altitude, req.PointOrder, id, actions)
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, tx.Commit()
+}
+
+// SelectApplicationRevisions возвращает прежние состояния заявки, от старых к новым.
+func (a *ApplicationRepository) SelectApplicationRevisions(id int) ([]structures.ApplicationRevision, error) {
+	revisions := []structures.ApplicationRevision{}
+
+	rows, err := a.DB.Query(`SELECT revision, start_date, end_date, drone_id, status, COALESCE(rejection_reason, ''),
+								route, amended_by, created_at
+							FROM application_revisions
+							WHERE application_id = ?
+							ORDER BY revision`, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revision structures.ApplicationRevision
+		var route, createdAtBytes []byte
+
+		err := rows.Scan(&revision.Revision, &revision.StartDate, &revision.EndDate, &revision.DroneId, &revision.Status,
+			&revision.RejectionReason, &route, &revision.AmendedBy, &createdAtBytes)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		if err := json.Unmarshal(route, &revision.Route); err != nil {
+			return nil, err
+		}
+
+		revision.AmendedAt, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
+		if err != nil {
+			log.Error("invalid datetime format from DB:", err)
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func selectRoute(db querier, applicationId int) ([]structures.Routes, error) {
+	route := []structures.Routes{}
+
+	rows, err := db.Query(`SELECT route_id, latitude, longtitude, altitude, point_order, application_id, actions
+							FROM Route
+							WHERE application_id = ?
+							ORDER BY point_order, route_id`, applicationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var point structures.Routes
+		var actions []byte
+
+		err := rows.Scan(&point.Id, &point.Latitude, &point.Longitude, &point.Altitude, &point.Point_order, &point.Application_id, &actions)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(actions) > 0 {
+			if err := json.Unmarshal(actions, &point.Actions); err != nil {
+				return nil, fmt.Errorf("failed to parse actions of route point %d: %w", point.Id, err)
+			}
+		}
+
+		route = append(route, point)
+	}
+
+	return route, rows.Err()
+}
+
+// routeActions готовит действия точки к записи в JSON-колонку; пустой список
+// хранится как NULL.
+func routeActions(actions []structures.WaypointAction) (interface{}, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode waypoint actions: %w", err)
+	}
+
+	return data, nil
+}
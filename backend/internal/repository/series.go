@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// CreateSeries сохраняет серию и по заявке на каждое вхождение, с копией
+// маршрута. Все или ничего: при ошибке не остается частично созданной серии.
+func (a *ApplicationRepository) CreateSeries(req structures.CreateSeriesRequest, occurrences []structures.Occurrence) (*structures.ApplicationSeries, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	res, err := tx.Exec(`INSERT INTO application_series (pilot_id, drone_id, rrule, status, created_at)
+						VALUES (?, ?, ?, ?, ?)`,
+		req.PilotId, req.DroneId, req.RRule, structures.SeriesActive, now)
+	if err != nil {
+		log.Error(err)
+		return nil, translate(err)
+	}
+
+	seriesId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	series := &structures.ApplicationSeries{
+		Id:          int(seriesId),
+		PilotId:     req.PilotId,
+		DroneId:     req.DroneId,
+		RRule:       req.RRule,
+		Status:      structures.SeriesActive,
+		CreatedAt:   now,
+		Occurrences: make([]structures.Occurrence, 0, len(occurrences)),
+	}
+
+	actions, err := routeActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, occurrence := range occurrences {
+		res, err := tx.Exec(`
+			INSERT INTO Application (start_date, end_date, status, created_at, last_update, pilot_id, drone_id, tested, series_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			occurrence.StartDate, occurrence.EndDate, structures.StatusPending, now, now, req.PilotId, req.DroneId, req.Tested, seriesId)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		applicationId, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO Route (latitude, longtitude, altitude, point_order, application_id, actions)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			req.Latitude, req.Longitude, req.Altitude, req.PointOrder, applicationId, actions)
+		if err != nil {
+			return nil, err
+		}
+
+		occurrence.ApplicationId = int(applicationId)
+		occurrence.Status = structures.StatusPending
+		series.Occurrences = append(series.Occurrences, occurrence)
+	}
+
+	return series, tx.Commit()
+}
+
+func (a *ApplicationRepository) SelectSeries(id int) (*structures.ApplicationSeries, error) {
+	var series structures.ApplicationSeries
+	var cancelReason sql.NullString
+	var createdAtBytes []byte
+
+	err := a.DB.QueryRow(`SELECT series_id, pilot_id, drone_id, rrule, status, cancel_reason, created_at
+							FROM application_series
+							WHERE series_id = ?`, id).Scan(
+		&series.Id, &series.PilotId, &series.DroneId, &series.RRule, &series.Status, &cancelReason, &createdAtBytes)
+	if err != nil {
+		return nil, translate(err)
+	}
+	series.CancelReason = cancelReason.String
+
+	series.CreatedAt, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
+	if err != nil {
+		log.Error("invalid datetime format from DB:", err)
+		return nil, err
+	}
+
+	rows, err := a.DB.Query(`SELECT application_id, start_date, end_date, status, COALESCE(rejection_reason, '')
+							FROM Application
+							WHERE series_id = ?
+							ORDER BY start_date, application_id`, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	series.Occurrences = []structures.Occurrence{}
+	for rows.Next() {
+		var occurrence structures.Occurrence
+
+		err := rows.Scan(&occurrence.ApplicationId, &occurrence.StartDate, &occurrence.EndDate, &occurrence.Status, &occurrence.RejectionReason)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		series.Occurrences = append(series.Occurrences, occurrence)
+	}
+
+	return &series, rows.Err()
+}
+
+// CancelSeries отменяет серию и все ее вхождения, которые еще не взлетели.
+// Уже выполняемый полет не прерывается: для этого есть отмена заявки.
+// Возвращает число отмененных вхождений.
+func (a *ApplicationRepository) CancelSeries(id int, reason string) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status structures.SeriesStatus
+	err = tx.QueryRow("SELECT status FROM application_series WHERE series_id = ? FOR UPDATE", id).Scan(&status)
+	if err != nil {
+		return 0, translate(err)
+	}
+
+	if status != structures.SeriesActive {
+		return 0, ErrInvalidState
+	}
+
+	_, err = tx.Exec("UPDATE application_series SET status = ?, cancel_reason = ? WHERE series_id = ?",
+		structures.SeriesCancelled, reason, id)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	res, err := tx.Exec(`UPDATE Application
+						SET status = ?, rejection_reason = ?, last_update = ?, revision = revision + 1
+						WHERE series_id = ? AND status IN (?, ?, ?)`,
+		structures.StatusCancelled, reason, time.Now(), id,
+		structures.StatusPending, structures.StatusProcessing, structures.StatusApproved)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	cancelled, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(cancelled), tx.Commit()
+}
+
+// SkipOccurrence отменяет одно вхождение серии до взлета, остальные
+// вхождения остаются в силе.
+func (a *ApplicationRepository) SkipOccurrence(seriesId, applicationId int, reason string) error {
+	res, err := a.DB.Exec(`UPDATE Application
+							SET status = ?, rejection_reason = ?, last_update = ?, revision = revision + 1
+							WHERE application_id = ? AND series_id = ? AND status IN (?, ?, ?)`,
+		structures.StatusCancelled, reason, time.Now(), applicationId, seriesId,
+		structures.StatusPending, structures.StatusProcessing, structures.StatusApproved)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Отличаем вхождение, которого нет в серии, от уже взлетевшего или завершенного
+	var exists int
+	err = a.DB.QueryRow("SELECT 1 FROM Application WHERE application_id = ? AND series_id = ?",
+		applicationId, seriesId).Scan(&exists)
+	if err != nil {
+		return translate(err)
+	}
+
+	return ErrInvalidState
+}
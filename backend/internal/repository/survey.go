@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// CreateSurveyApplication сохраняет заявку на съемку: параметры облета и
+// построенный по ним маршрут. Дальше заявка проходит ту же проверку и
+// симуляцию, что и обычная. Возвращает id заявки.
+func (a *ApplicationRepository) CreateSurveyApplication(req structures.CreateSurveyRequest, route []structures.Routes) (int, error) {
+	polygon, err := json.Marshal(req.Polygon)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		INSERT INTO Application (start_date, end_date, status, created_at, last_update, pilot_id, drone_id, tested, mission_type)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		req.StartDate, req.EndDate, structures.StatusPending, time.Now(), time.Now(), req.PilotId, req.DroneId, req.Tested,
+		structures.MissionSurvey)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	applicationId, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO survey_missions (application_id, polygon, altitude, footprint_width, overlap)
+		VALUES (?, ?, ?, ?, ?)`,
+		applicationId, polygon, req.Altitude, req.FootprintWidth, req.Overlap)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	for _, point := range route {
+		_, err = tx.Exec(`
+			INSERT INTO Route (latitude, longtitude, altitude, point_order, application_id)
+			VALUES (?, ?, ?, ?, ?)`,
+			point.Latitude, point.Longitude, point.Altitude, point.Point_order, applicationId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int(applicationId), tx.Commit()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+const telemetryTimeLayout = "2006-01-02 15:04:05.999999"
+
+func (a *ApplicationRepository) SelectApplicationPilot(id int) (int, error) {
+	var pilotId int
+
+	err := a.DB.QueryRow("SELECT pilot_id FROM Application WHERE application_id = ?", id).Scan(&pilotId)
+	if err != nil {
+		return 0, translate(err)
+	}
+
+	return pilotId, nil
+}
+
+func (a *ApplicationRepository) SelectTrack(applicationId int, from, to time.Time) ([]structures.TrackPoint, error) {
+	track := []structures.TrackPoint{}
+
+	rows, err := a.DB.Query(`SELECT drone_id, latitude, longitude, altitude, speed, heading, route_progress, timestamp
+							FROM drone_telemetry
+							WHERE application_id = ? AND timestamp BETWEEN ? AND ?
+							ORDER BY timestamp ASC, telemetry_id ASC`, applicationId, from, to)
+	if err != nil {
+		log.Error(err)
+		return track, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var point structures.TrackPoint
+
+		var timestampBytes []byte
+		err := rows.Scan(&point.DroneId, &point.Latitude, &point.Longitude, &point.Altitude,
+			&point.Speed, &point.Heading, &point.RouteProgress, &timestampBytes)
+		if err != nil {
+			log.Error(err)
+			return track, err
+		}
+
+		point.Timestamp, err = time.Parse(telemetryTimeLayout, string(timestampBytes))
+		if err != nil {
+			log.Error("invalid datetime format from DB:", err)
+			return track, err
+		}
+
+		track = append(track, point)
+	}
+
+	return track, rows.Err()
+}
+
+func (a *ApplicationRepository) SelectFlightEvents(applicationId int) ([]structures.FlightEvent, error) {
+	events := []structures.FlightEvent{}
+
+	rows, err := a.DB.Query(`SELECT event_id, application_id, drone_id, event_type, alert_level, message, details,
+								latitude, longitude, altitude, created_at
+							FROM flight_history
+							WHERE application_id = ?
+							ORDER BY created_at ASC, event_id ASC`, applicationId)
+	if err != nil {
+		log.Error(err)
+		return events, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var event structures.FlightEvent
+
+		var details, createdAtBytes []byte
+		err := rows.Scan(&event.Id, &event.ApplicationId, &event.DroneId, &event.EventType, &event.AlertLevel,
+			&event.Message, &details, &event.Latitude, &event.Longitude, &event.Altitude, &createdAtBytes)
+		if err != nil {
+			log.Error(err)
+			return events, err
+		}
+		if len(details) > 0 {
+			event.Details = details
+		}
+
+		event.CreatedAt, err = time.Parse(telemetryTimeLayout, string(createdAtBytes))
+		if err != nil {
+			log.Error("invalid datetime format from DB:", err)
+			return events, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
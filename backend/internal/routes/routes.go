@@ -2,8 +2,10 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/api"
 	"github.com/nxbodyevzncvre/decenthack/internal/config"
 	"github.com/nxbodyevzncvre/decenthack/internal/handlers"
+	"github.com/nxbodyevzncvre/decenthack/internal/openapi"
 	"github.com/nxbodyevzncvre/decenthack/internal/repository"
 	"github.com/nxbodyevzncvre/decenthack/pkg/jwt/middleware"
 )
@@ -29,23 +31,39 @@ func InitRoutes(
 	applicationHandler := handlers.NewApplicationHandler(applicationRepo, *cfg)
 	zonesHandler := handlers.NewZonesHandler(zonesRepo, *cfg)
 
-	pilot.Post("/sign-in", pilotHandler.SignIn)
-	pilot.Post("/sign-up", pilotHandler.SignUp)
+	validate := openapi.MustLoad(api.OpenAPI).Validator()
 
-	drone.Post("/create", droneHandler.CreateDrone)
-	drone.Get("/pilot", pilotHandler.PilotById)
-	drone.Get("/drone/:id", droneHandler.DroneById)
-	drone.Get("/drones", droneHandler.AllDrones)
-	drone.Delete("/delete/:id", droneHandler.DeleteDrone)
+	pilot.Post("/sign-in", validate, pilotHandler.SignIn)
+	pilot.Post("/sign-up", validate, pilotHandler.SignUp)
 
-	application.Post("/create", applicationHandler.CreateApplication)
-	application.Delete("/delete/:id", applicationHandler.DeleteApplication)
-	application.Get("/status", applicationHandler.ApplicationStatus)
-	application.Get("/applications", applicationHandler.AllApplications)
+	drone.Post("/create", validate, droneHandler.CreateDrone)
+	drone.Get("/pilot", validate, pilotHandler.PilotById)
+	drone.Get("/drone/:id", validate, droneHandler.DroneById)
+	drone.Get("/drones", validate, droneHandler.AllDrones)
+	drone.Delete("/delete/:id", validate, droneHandler.DeleteDrone)
 
-	zones.Post("/create", zonesHandler.CreateZone)
-	zones.Get("/", zonesHandler.AllZones)
-	zones.Delete("/delete/:id", zonesHandler.DeleteZone)
+	application.Post("/create", validate, applicationHandler.CreateApplication)
+	application.Delete("/delete/:id", validate, applicationHandler.DeleteApplication)
+	application.Get("/status", validate, applicationHandler.ApplicationStatus)
+	application.Get("/applications", validate, applicationHandler.AllApplications)
+	application.Post("/survey", validate, applicationHandler.CreateSurvey)
+	application.Post("/series", validate, applicationHandler.CreateSeries)
+	application.Get("/series/:id", validate, applicationHandler.Series)
+	application.Post("/series/:id/cancel", validate, applicationHandler.CancelSeries)
+	application.Post("/series/:id/occurrences/:occurrence/skip", validate, applicationHandler.SkipOccurrence)
+	application.Put("/:id", validate, applicationHandler.UpdateApplication)
+	application.Post("/:id/cancel", validate, applicationHandler.CancelApplication)
+	application.Get("/:id/revisions", validate, applicationHandler.ApplicationRevisions)
+	application.Get("/:id/track", validate, applicationHandler.ApplicationTrack)
+
+	zones.Post("/create", validate, zonesHandler.CreateZone)
+	zones.Get("/", validate, zonesHandler.AllZones)
+	zones.Delete("/delete/:id", validate, zonesHandler.DeleteZone)
+
+	app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(api.OpenAPI)
+	})
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
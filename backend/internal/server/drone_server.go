@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	dronepb "github.com/nxbodyevzncvre/decenthack/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ApplicationSource interface {
+	SelectApplication(id int) (*structures.Application, error)
+}
+
+// DroneServer отдает заявки и живые координаты полета внешним наземным
+// станциям управления, которым не нужен браузерный WebSocket.
+type DroneServer struct {
+	dronepb.UnimplementedDroneServiceServer
+	applications ApplicationSource
+	positions    *positionFeed
+}
+
+func NewDroneServer(applications ApplicationSource, positions *positionFeed) *DroneServer {
+	return &DroneServer{
+		applications: applications,
+		positions:    positions,
+	}
+}
+
+func (s *DroneServer) GetApplication(ctx context.Context, req *dronepb.GetApplicationRequest) (*dronepb.ApplicationResponse, error) {
+	application, err := s.loadApplication(req.ApplicationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dronepb.ApplicationResponse{Application: applicationToProto(application)}, nil
+}
+
+// SimulateFlight транслирует координаты дрона по заявке, пока полет не
+// завершится или клиент не отключится. Название метода осталось от
+// прототипа: координаты реальные, из уведомлений процессора.
+func (s *DroneServer) SimulateFlight(req *dronepb.FlightSimulationRequest, stream grpc.ServerStreamingServer[dronepb.Coordinates]) error {
+	application, err := s.loadApplication(req.ApplicationId)
+	if err != nil {
+		return err
+	}
+
+	switch application.Status {
+	case structures.StatusCompleted, structures.StatusCancelled, structures.StatusRejected:
+		return status.Errorf(codes.FailedPrecondition, "application %d is %s", application.Id, application.Status)
+	}
+
+	coordinates, unsubscribe := s.positions.subscribe(application.Id)
+	defer unsubscribe()
+
+	log.Printf("Ground control subscribed to coordinates of application %d", application.Id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case position, ok := <-coordinates:
+			if !ok {
+				log.Printf("Coordinate stream for application %d finished", application.Id)
+				return nil
+			}
+			if err := stream.Send(position); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *DroneServer) loadApplication(applicationId int32) (*structures.Application, error) {
+	if applicationId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "application_id must be positive")
+	}
+
+	application, err := s.applications.SelectApplication(int(applicationId))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "application %d not found", applicationId)
+	}
+	if err != nil {
+		log.Printf("Error loading application %d: %v", applicationId, err)
+		return nil, status.Error(codes.Internal, "failed to load application")
+	}
+
+	return application, nil
+}
+
+func applicationToProto(application *structures.Application) *dronepb.Application {
+	return &dronepb.Application{
+		ApplicationId:       int32(application.Id),
+		StartDate:           application.Start_date,
+		EndDate:             application.End_date,
+		Status:              string(application.Status),
+		RejectionReason:     application.Rejection_reason,
+		RestrictedZoneCheck: int32(application.Restricted_zone_check),
+		CreatedAt:           application.Created_at.Format(time.RFC3339),
+		LastUpdate:          application.Last_update.Format(time.RFC3339),
+		PilotId:             int32(application.Pilot_id),
+		DroneId:             int32(application.Drone_id),
+	}
+}
@@ -2,42 +2,77 @@ package server
 
 import (
 	"context"
+	"io"
 	"log"
 	"net"
-
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	ws "github.com/nxbodyevzncvre/decenthack/internal/websocket"
+	dronepb "github.com/nxbodyevzncvre/decenthack/pkg/pb"
 	pb "github.com/nxbodyevzncvre/decenthack/proto"
+	managementv1 "github.com/nxbodyevzncvre/decenthack/proto/management/v1"
 	"google.golang.org/grpc"
 )
 
+// telemetryWindow — сколько батчей телеметрии клиент может отправить без подтверждения.
+const telemetryWindow = 8
+
 type WebSocketHub interface {
 	Broadcast(message []byte)
 	BroadcastJSON(data interface{}) error
+	Publish(meta ws.EventMeta, data interface{}) error
 }
 
 type FlightNotificationServer struct {
 	pb.UnimplementedFlightNotificationServiceServer
 	websocketHub WebSocketHub
+	dedup        *deduplicator
+	positions    *positionFeed
 }
 
-func NewFlightNotificationServer(wsHub WebSocketHub) *FlightNotificationServer {
+func NewFlightNotificationServer(wsHub WebSocketHub, processed ProcessedNotificationStore, positions *positionFeed) *FlightNotificationServer {
 	return &FlightNotificationServer{
 		websocketHub: wsHub,
+		dedup:        newDeduplicator(processed),
+		positions:    positions,
+	}
+}
+
+// publishOnce рассылает событие, если событие с таким eventId еще не было
+// принято. На повтор отвечаем так же, как на первую доставку.
+func (s *FlightNotificationServer) publishOnce(eventId string, meta ws.EventMeta, data events.Typed) error {
+	record := structures.ProcessedNotification{
+		EventType:     data.EventType(),
+		ApplicationId: meta.ApplicationId,
 	}
+
+	duplicate, err := s.dedup.do(eventId, record, !meta.Coalesce, func() error {
+		return s.websocketHub.Publish(meta, data)
+	})
+	if duplicate {
+		log.Printf("Ignoring duplicate %s notification %s for application %d", record.EventType, eventId, meta.ApplicationId)
+	}
+	return err
 }
 
 func (s *FlightNotificationServer) NotifyStatusUpdate(ctx context.Context, req *pb.StatusUpdateRequest) (*pb.StatusUpdateResponse, error) {
 	log.Printf("Received status update for application %d: %s", req.ApplicationId, req.Status)
 
-	notification := map[string]interface{}{
-		"type":             "status_update",
-		"application_id":   req.ApplicationId,
-		"status":           req.Status,
-		"message":          req.Message,
-		"rejection_reason": req.RejectionReason,
-		"timestamp":        req.Timestamp.AsTime(),
+	notification := events.StatusUpdate{
+		Header:          events.NewHeader(events.TypeStatusUpdate),
+		ApplicationId:   int(req.ApplicationId),
+		Status:          req.Status,
+		Message:         req.Message,
+		RejectionReason: req.RejectionReason,
+		Timestamp:       req.Timestamp.AsTime(),
 	}
 
-	err := s.websocketHub.BroadcastJSON(notification)
+	meta := ws.EventMeta{ApplicationId: int(req.ApplicationId)}
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting status update: %v", err)
 		return &pb.StatusUpdateResponse{
@@ -53,18 +88,20 @@ func (s *FlightNotificationServer) NotifyStatusUpdate(ctx context.Context, req *
 func (s *FlightNotificationServer) NotifyFlightStarted(ctx context.Context, req *pb.FlightStartedRequest) (*pb.FlightStartedResponse, error) {
 	log.Printf("Received flight started notification for application %d", req.ApplicationId)
 
-	notification := map[string]interface{}{
-		"type":               "flight_started",
-		"application_id":     req.ApplicationId,
-		"drone_id":           req.DroneId,
-		"pilot_id":           req.PilotId,
-		"route":              convertRouteFromProto(req.Route),
-		"current_position":   convertPositionFromProto(req.CurrentPosition),
-		"start_time":         req.StartTime.AsTime(),
-		"estimated_end_time": req.EstimatedEndTime.AsTime(),
+	notification := events.FlightStarted{
+		Header:           events.NewHeader(events.TypeFlightStarted),
+		ApplicationId:    int(req.ApplicationId),
+		DroneId:          int(req.DroneId),
+		PilotId:          int(req.PilotId),
+		Route:            convertRouteFromProto(req.Route),
+		CurrentPosition:  convertPositionFromProto(req.CurrentPosition),
+		StartTime:        req.StartTime.AsTime(),
+		EstimatedEndTime: timePointer(req.EstimatedEndTime.AsTime()),
 	}
 
-	err := s.websocketHub.BroadcastJSON(notification)
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.CurrentPosition)
+	meta.PilotId = int(req.PilotId)
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting flight started: %v", err)
 		return &pb.FlightStartedResponse{
@@ -78,20 +115,7 @@ func (s *FlightNotificationServer) NotifyFlightStarted(ctx context.Context, req
 }
 
 func (s *FlightNotificationServer) UpdateDronePosition(ctx context.Context, req *pb.DronePositionRequest) (*pb.DronePositionResponse, error) {
-	notification := map[string]interface{}{
-		"type":           "position_update",
-		"application_id": req.ApplicationId,
-		"drone_id":       req.DroneId,
-		"latitude":       req.Latitude,
-		"longitude":      req.Longitude,
-		"altitude":       req.Altitude,
-		"speed":          req.Speed,
-		"heading":        req.Heading,
-		"route_progress": req.RouteProgress,
-		"timestamp":      req.Timestamp.AsTime(),
-	}
-
-	err := s.websocketHub.BroadcastJSON(notification)
+	err := s.publishPosition(req)
 	if err != nil {
 		log.Printf("Error broadcasting position update: %v", err)
 		return &pb.DronePositionResponse{
@@ -103,19 +127,92 @@ func (s *FlightNotificationServer) UpdateDronePosition(ctx context.Context, req
 	return &pb.DronePositionResponse{Success: true}, nil
 }
 
+// StreamTelemetry принимает позиции батчами по долгоживущему потоку и
+// подтверждает каждый батч. Окно ограничивает число неподтвержденных батчей
+// у клиента, поэтому при медленной рассылке клиент копит позиции у себя.
+func (s *FlightNotificationServer) StreamTelemetry(stream pb.FlightNotificationService_StreamTelemetryServer) error {
+	log.Println("Telemetry stream opened")
+
+	for {
+		batch, err := stream.Recv()
+		if err == io.EOF {
+			log.Println("Telemetry stream closed by client")
+			return nil
+		}
+		if err != nil {
+			log.Printf("Telemetry stream error: %v", err)
+			return err
+		}
+
+		ack := &pb.TelemetryAck{
+			Sequence: batch.Sequence,
+			Success:  true,
+			Window:   telemetryWindow,
+		}
+
+		for _, position := range batch.Positions {
+			if err := s.publishPosition(position); err != nil {
+				log.Printf("Error broadcasting position update for application %d: %v", position.ApplicationId, err)
+				ack.Success = false
+				ack.ErrorMessage = "Failed to broadcast some positions"
+				continue
+			}
+			ack.Accepted++
+		}
+
+		if err := stream.Send(ack); err != nil {
+			log.Printf("Failed to acknowledge telemetry batch %d: %v", batch.Sequence, err)
+			return err
+		}
+	}
+}
+
+func (s *FlightNotificationServer) publishPosition(req *pb.DronePositionRequest) error {
+	notification := events.PositionUpdate{
+		Header:            events.NewHeader(events.TypePositionUpdate),
+		ApplicationId:     int(req.ApplicationId),
+		DroneId:           int(req.DroneId),
+		Latitude:          req.Latitude,
+		Longitude:         req.Longitude,
+		Altitude:          req.Altitude,
+		Speed:             req.Speed,
+		Heading:           req.Heading,
+		RouteProgress:     req.RouteProgress,
+		Timestamp:         req.Timestamp.AsTime(),
+		EstimatedEndTime:  timePointer(req.EstimatedEndTime.AsTime()),
+		DistanceRemaining: req.DistanceRemaining,
+	}
+
+	meta := ws.EventMeta{
+		ApplicationId: int(req.ApplicationId),
+		DroneId:       int(req.DroneId),
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		HasPosition:   true,
+		Coalesce:      true,
+	}
+	if err := s.publishOnce(req.EventId, meta, notification); err != nil {
+		return err
+	}
+
+	s.positions.publish(int(req.ApplicationId), req.Latitude, req.Longitude, req.Altitude, req.Timestamp.AsTime())
+	return nil
+}
+
 func (s *FlightNotificationServer) NotifyFlightCompleted(ctx context.Context, req *pb.FlightCompletedRequest) (*pb.FlightCompletedResponse, error) {
 	log.Printf("Received flight completed notification for application %d", req.ApplicationId)
 
-	notification := map[string]interface{}{
-		"type":              "flight_completed",
-		"application_id":    req.ApplicationId,
-		"drone_id":          req.DroneId,
-		"final_position":    convertPositionFromProto(req.FinalPosition),
-		"completion_time":   req.CompletionTime.AsTime(),
-		"completion_status": req.CompletionStatus,
+	notification := events.FlightCompleted{
+		Header:           events.NewHeader(events.TypeFlightCompleted),
+		ApplicationId:    int(req.ApplicationId),
+		DroneId:          int(req.DroneId),
+		FinalPosition:    convertPositionFromProto(req.FinalPosition),
+		CompletionTime:   req.CompletionTime.AsTime(),
+		CompletionStatus: req.CompletionStatus,
 	}
 
-	err := s.websocketHub.BroadcastJSON(notification)
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.FinalPosition)
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting flight completed: %v", err)
 		return &pb.FlightCompletedResponse{
@@ -124,6 +221,11 @@ func (s *FlightNotificationServer) NotifyFlightCompleted(ctx context.Context, re
 		}, nil
 	}
 
+	if final := req.FinalPosition; final != nil {
+		s.positions.publish(int(req.ApplicationId), final.Latitude, final.Longitude, final.Altitude, final.Timestamp.AsTime())
+	}
+	s.positions.finish(int(req.ApplicationId))
+
 	log.Printf("Flight completed notification broadcasted successfully")
 	return &pb.FlightCompletedResponse{Success: true}, nil
 }
@@ -132,21 +234,22 @@ func (s *FlightNotificationServer) NotifyRestrictedZoneProximity(ctx context.Con
 	log.Printf("Received restricted zone alert for drone %d: %s level, %.1fm from zone '%s'",
 		req.DroneId, req.AlertLevel, req.Distance, req.ZoneName)
 
-	notification := map[string]interface{}{
-		"type":           "restricted_zone_alert",
-		"application_id": req.ApplicationId,
-		"drone_id":       req.DroneId,
-		"zone_name":      req.ZoneName,
-		"zone_latitude":  req.ZoneLatitude,
-		"zone_longitude": req.ZoneLongitude,
-		"zone_radius":    req.ZoneRadius,
-		"alert_level":    req.AlertLevel,
-		"distance":       req.Distance,
-		"drone_position": convertPositionFromProto(req.DronePosition),
-		"timestamp":      req.Timestamp.AsTime(),
-	}
-
-	err := s.websocketHub.BroadcastJSON(notification)
+	notification := events.RestrictedZoneAlert{
+		Header:        events.NewHeader(events.TypeRestrictedZoneAlert),
+		ApplicationId: int(req.ApplicationId),
+		DroneId:       int(req.DroneId),
+		ZoneName:      req.ZoneName,
+		ZoneLatitude:  req.ZoneLatitude,
+		ZoneLongitude: req.ZoneLongitude,
+		ZoneRadius:    int(req.ZoneRadius),
+		AlertLevel:    req.AlertLevel,
+		Distance:      req.Distance,
+		DronePosition: convertPositionFromProto(req.DronePosition),
+		Timestamp:     req.Timestamp.AsTime(),
+	}
+
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.DronePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting restricted zone alert: %v", err)
 		return &pb.RestrictedZoneAlertResponse{
@@ -162,16 +265,17 @@ func (s *FlightNotificationServer) NotifyRestrictedZoneProximity(ctx context.Con
 func (s *FlightNotificationServer) NotifyFlightPaused(ctx context.Context, req *pb.FlightPausedRequest) (*pb.FlightPausedResponse, error) {
 	log.Printf("Received flight paused notification for application %d: %s", req.ApplicationId, req.PauseReason)
 
-	notification := map[string]interface{}{
-		"type":           "flight_paused",
-		"application_id": req.ApplicationId,
-		"drone_id":       req.DroneId,
-		"pause_position": convertPositionFromProto(req.PausePosition),
-		"pause_time":     req.PauseTime.AsTime(),
-		"pause_reason":   req.PauseReason,
+	notification := events.FlightPaused{
+		Header:        events.NewHeader(events.TypeFlightPaused),
+		ApplicationId: int(req.ApplicationId),
+		DroneId:       int(req.DroneId),
+		PausePosition: convertPositionFromProto(req.PausePosition),
+		PauseTime:     req.PauseTime.AsTime(),
+		PauseReason:   req.PauseReason,
 	}
 
-	err := s.websocketHub.BroadcastJSON(notification)
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.PausePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting flight paused: %v", err)
 		return &pb.FlightPausedResponse{
@@ -187,16 +291,17 @@ func (s *FlightNotificationServer) NotifyFlightPaused(ctx context.Context, req *
 func (s *FlightNotificationServer) NotifyFlightResumed(ctx context.Context, req *pb.FlightResumedRequest) (*pb.FlightResumedResponse, error) {
 	log.Printf("Received flight resumed notification for application %d: %s", req.ApplicationId, req.ResumeReason)
 
-	notification := map[string]interface{}{
-		"type":            "flight_resumed",
-		"application_id":  req.ApplicationId,
-		"drone_id":        req.DroneId,
-		"resume_position": convertPositionFromProto(req.ResumePosition),
-		"resume_time":     req.ResumeTime.AsTime(),
-		"resume_reason":   req.ResumeReason,
+	notification := events.FlightResumed{
+		Header:         events.NewHeader(events.TypeFlightResumed),
+		ApplicationId:  int(req.ApplicationId),
+		DroneId:        int(req.DroneId),
+		ResumePosition: convertPositionFromProto(req.ResumePosition),
+		ResumeTime:     req.ResumeTime.AsTime(),
+		ResumeReason:   req.ResumeReason,
 	}
 
-	err := s.websocketHub.BroadcastJSON(notification)
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.ResumePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
 	if err != nil {
 		log.Printf("Error broadcasting flight resumed: %v", err)
 		return &pb.FlightResumedResponse{
@@ -209,46 +314,196 @@ func (s *FlightNotificationServer) NotifyFlightResumed(ctx context.Context, req
 	return &pb.FlightResumedResponse{Success: true}, nil
 }
 
-func convertRouteFromProto(protoRoute []*pb.RoutePoint) []map[string]interface{} {
-	route := make([]map[string]interface{}, len(protoRoute))
+func (s *FlightNotificationServer) NotifyTrafficConflict(ctx context.Context, req *pb.TrafficConflictRequest) (*pb.TrafficConflictResponse, error) {
+	log.Printf("Received traffic conflict alert for application %d vs %d: %s level, %.1fm horizontal, %.1fm vertical",
+		req.ApplicationId, req.IntruderApplicationId, req.AlertLevel, req.HorizontalSeparation, req.VerticalSeparation)
+
+	notification := events.TrafficConflict{
+		Header:                events.NewHeader(events.TypeTrafficConflict),
+		ApplicationId:         int(req.ApplicationId),
+		DroneId:               int(req.DroneId),
+		IntruderApplicationId: int(req.IntruderApplicationId),
+		IntruderDroneId:       int(req.IntruderDroneId),
+		AlertLevel:            req.AlertLevel,
+		HorizontalSeparation:  req.HorizontalSeparation,
+		VerticalSeparation:    req.VerticalSeparation,
+		Resolution:            req.Resolution,
+		DronePosition:         convertPositionFromProto(req.DronePosition),
+		IntruderPosition:      convertPositionFromProto(req.IntruderPosition),
+		Timestamp:             req.Timestamp.AsTime(),
+	}
+
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.DronePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
+	if err != nil {
+		log.Printf("Error broadcasting traffic conflict: %v", err)
+		return &pb.TrafficConflictResponse{
+			Success:      false,
+			ErrorMessage: "Failed to broadcast notification",
+		}, nil
+	}
+
+	log.Printf("Traffic conflict alert broadcasted successfully")
+	return &pb.TrafficConflictResponse{Success: true}, nil
+}
+
+func (s *FlightNotificationServer) NotifyRouteDeviation(ctx context.Context, req *pb.RouteDeviationRequest) (*pb.RouteDeviationResponse, error) {
+	log.Printf("Received route deviation alert for application %d: %s %s",
+		req.ApplicationId, req.DeviationType, req.AlertLevel)
+
+	notification := events.RouteDeviation{
+		Header:               events.NewHeader(events.TypeRouteDeviation),
+		ApplicationId:        int(req.ApplicationId),
+		DroneId:              int(req.DroneId),
+		DeviationType:        req.DeviationType,
+		AlertLevel:           req.AlertLevel,
+		LateralDeviation:     req.LateralDeviation,
+		VerticalDeviation:    req.VerticalDeviation,
+		TimeDeviationSeconds: req.TimeDeviationSeconds,
+		Leg:                  int(req.Leg),
+		DronePosition:        convertPositionFromProto(req.DronePosition),
+		Timestamp:            req.Timestamp.AsTime(),
+	}
+
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.DronePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
+	if err != nil {
+		log.Printf("Error broadcasting route deviation: %v", err)
+		return &pb.RouteDeviationResponse{
+			Success:      false,
+			ErrorMessage: "Failed to broadcast notification",
+		}, nil
+	}
+
+	log.Printf("Route deviation alert broadcasted successfully")
+	return &pb.RouteDeviationResponse{Success: true}, nil
+}
+
+func (s *FlightNotificationServer) NotifyWaypointAction(ctx context.Context, req *pb.WaypointActionRequest) (*pb.WaypointActionResponse, error) {
+	action := convertActionFromProto(req.Action)
+	log.Printf("Received waypoint action for application %d: %s %s at waypoint %d",
+		req.ApplicationId, action.Type, req.Phase, req.Waypoint)
+
+	notification := events.WaypointAction{
+		Header:        events.NewHeader(events.TypeWaypointAction),
+		ApplicationId: int(req.ApplicationId),
+		DroneId:       int(req.DroneId),
+		Waypoint:      int(req.Waypoint),
+		Action:        action,
+		Phase:         req.Phase,
+		DronePosition: convertPositionFromProto(req.DronePosition),
+		Timestamp:     req.Timestamp.AsTime(),
+	}
+
+	meta := eventMeta(req.ApplicationId, req.DroneId, req.DronePosition)
+	err := s.publishOnce(req.EventId, meta, notification)
+	if err != nil {
+		log.Printf("Error broadcasting waypoint action: %v", err)
+		return &pb.WaypointActionResponse{
+			Success:      false,
+			ErrorMessage: "Failed to broadcast notification",
+		}, nil
+	}
+
+	return &pb.WaypointActionResponse{Success: true}, nil
+}
+
+func convertRouteFromProto(protoRoute []*pb.RoutePoint) []events.RoutePoint {
+	route := make([]events.RoutePoint, len(protoRoute))
 	for i, point := range protoRoute {
-		route[i] = map[string]interface{}{
-			"id":             point.Id,
-			"latitude":       point.Latitude,
-			"longitude":      point.Longitude,
-			"altitude":       point.Altitude,
-			"point_order":    point.PointOrder,
-			"application_id": point.ApplicationId,
+		route[i] = events.RoutePoint{
+			Id:            int(point.Id),
+			Latitude:      point.Latitude,
+			Longitude:     point.Longitude,
+			Altitude:      point.Altitude,
+			PointOrder:    int(point.PointOrder),
+			ApplicationId: int(point.ApplicationId),
+		}
+		for _, action := range point.Actions {
+			route[i].Actions = append(route[i].Actions, convertActionFromProto(action))
 		}
 	}
 	return route
 }
 
-func convertPositionFromProto(protoPos *pb.DronePosition) map[string]interface{} {
-	return map[string]interface{}{
-		"application_id": protoPos.ApplicationId,
-		"drone_id":       protoPos.DroneId,
-		"latitude":       protoPos.Latitude,
-		"longitude":      protoPos.Longitude,
-		"altitude":       protoPos.Altitude,
-		"speed":          protoPos.Speed,
-		"heading":        protoPos.Heading,
-		"route_progress": protoPos.RouteProgress,
-		"timestamp":      protoPos.Timestamp.AsTime(),
+func convertActionFromProto(protoAction *pb.WaypointAction) events.Action {
+	if protoAction == nil {
+		return events.Action{}
+	}
+
+	return events.Action{
+		Type:    protoAction.Type,
+		Seconds: protoAction.Seconds,
+		Radius:  protoAction.Radius,
+		Turns:   protoAction.Turns,
+		Speed:   protoAction.Speed,
+	}
+}
+
+func convertPositionFromProto(protoPos *pb.DronePosition) *events.Position {
+	if protoPos == nil {
+		return nil
+	}
+
+	return &events.Position{
+		ApplicationId: int(protoPos.ApplicationId),
+		DroneId:       int(protoPos.DroneId),
+		Latitude:      protoPos.Latitude,
+		Longitude:     protoPos.Longitude,
+		Altitude:      protoPos.Altitude,
+		Speed:         protoPos.Speed,
+		Heading:       protoPos.Heading,
+		RouteProgress: protoPos.RouteProgress,
+		Timestamp:     protoPos.Timestamp.AsTime(),
 	}
 }
 
-func StartGRPCServer(wsHub WebSocketHub, port string) error {
-	lis, err := net.Listen("tcp", ":"+port)
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
+
+func StartGRPCServer(
+	cfg *config.Config,
+	wsHub WebSocketHub,
+	notificationRepo *repository.NotificationRepository,
+	pilotRepo repository.PilotRepository,
+	droneRepo repository.DroneRepository,
+	applicationRepo repository.ApplicationRepository,
+	zonesRepo repository.ZonesRepository,
+) error {
+	opts, err := serverOptions(cfg.GRPC, managementJWTAuth(cfg.JWTSecretKey))
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
 	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
-	flightServer := NewFlightNotificationServer(wsHub)
+	grpcServer := grpc.NewServer(opts...)
+	positions := newPositionFeed()
+	flightServer := NewFlightNotificationServer(wsHub, notificationRepo, positions)
+	droneServer := NewDroneServer(&applicationRepo, positions)
+	managementServer := NewManagementServer(pilotRepo, droneRepo, applicationRepo, zonesRepo, *cfg)
 
 	pb.RegisterFlightNotificationServiceServer(grpcServer, flightServer)
+	dronepb.RegisterDroneServiceServer(grpcServer, droneServer)
+	managementv1.RegisterManagementServiceServer(grpcServer, managementServer)
 
-	log.Printf("gRPC server starting on port %s", port)
+	log.Printf("gRPC server starting on port %s", cfg.GRPC.Port)
 	return grpcServer.Serve(lis)
 }
+
+func eventMeta(applicationId, droneId int32, position *pb.DronePosition) ws.EventMeta {
+	meta := ws.EventMeta{
+		ApplicationId: int(applicationId),
+		DroneId:       int(droneId),
+	}
+	if position != nil {
+		meta.Latitude = position.Latitude
+		meta.Longitude = position.Longitude
+		meta.HasPosition = true
+	}
+	return meta
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"log"
+	"sync"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// recentEventsCapacity — сколько последних event_id помнится в памяти. Позиции
+// проверяются только здесь; остальные события дополнительно сверяются с базой,
+// потому что их повторы могут прийти после перезапуска сервера.
+const recentEventsCapacity = 10000
+
+type ProcessedNotificationStore interface {
+	SelectProcessedNotification(eventId string) (bool, error)
+	InsertProcessedNotification(notification *structures.ProcessedNotification) error
+}
+
+type idempotentCall struct {
+	eventId  string
+	done     chan struct{}
+	accepted bool
+}
+
+// deduplicator пропускает каждое событие один раз. Одновременно пришедший
+// дубликат ждет исхода первой попытки: если она не удалась, событие
+// обрабатывается заново.
+type deduplicator struct {
+	store ProcessedNotificationStore
+
+	mutex sync.Mutex
+	calls map[string]*idempotentCall
+	// order хранит сами попытки, а не event_id: после неудачной попытки
+	// повтор ставится в конец заново, и вытеснение старой записи не должно
+	// задеть новую.
+	order []*idempotentCall
+}
+
+func newDeduplicator(store ProcessedNotificationStore) *deduplicator {
+	return &deduplicator{
+		store: store,
+		calls: make(map[string]*idempotentCall),
+	}
+}
+
+// do вызывает handle, если событие eventId еще не было принято. Возвращает
+// true для дубликата уже принятого события.
+func (d *deduplicator) do(eventId string, record structures.ProcessedNotification, durable bool, handle func() error) (bool, error) {
+	if eventId == "" {
+		return false, handle()
+	}
+
+	for {
+		d.mutex.Lock()
+		call, ok := d.calls[eventId]
+		if !ok {
+			break
+		}
+		d.mutex.Unlock()
+
+		<-call.done
+		if call.accepted {
+			return true, nil
+		}
+	}
+
+	call := &idempotentCall{eventId: eventId, done: make(chan struct{})}
+	d.calls[eventId] = call
+	d.order = append(d.order, call)
+	d.evict()
+	d.mutex.Unlock()
+
+	defer close(call.done)
+
+	if durable && d.store != nil {
+		processed, err := d.store.SelectProcessedNotification(eventId)
+		if err != nil {
+			log.Printf("Failed to check notification %s for duplicates: %v", eventId, err)
+		}
+		if processed {
+			call.accepted = true
+			return true, nil
+		}
+	}
+
+	if err := handle(); err != nil {
+		d.mutex.Lock()
+		if d.calls[eventId] == call {
+			delete(d.calls, eventId)
+		}
+		d.mutex.Unlock()
+		return false, err
+	}
+
+	call.accepted = true
+
+	if durable && d.store != nil {
+		record.EventId = eventId
+		if err := d.store.InsertProcessedNotification(&record); err != nil {
+			log.Printf("Failed to record notification %s: %v", eventId, err)
+		}
+	}
+
+	return false, nil
+}
+
+// evict забывает самые старые события сверх recentEventsCapacity.
+// Вызывается под мьютексом.
+func (d *deduplicator) evict() {
+	for len(d.order) > recentEventsCapacity {
+		oldest := d.order[0]
+		d.order = d.order[1:]
+
+		// Неудачная попытка уже удалена, а событие могло прийти снова
+		if d.calls[oldest.eventId] != oldest {
+			continue
+		}
+
+		select {
+		case <-oldest.done:
+			delete(d.calls, oldest.eventId)
+		default:
+			// Попытка еще идет, забудем ее на следующем круге
+			d.order = append(d.order, oldest)
+			return
+		}
+	}
+}
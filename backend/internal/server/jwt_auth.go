@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	validatetoken "github.com/nxbodyevzncvre/decenthack/pkg/jwt/validateToken"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type principalKey struct{}
+
+// principal — пользователь из JWT, как Locals userId/role в REST.
+type principal struct {
+	UserId int
+	Role   structures.Role
+}
+
+func principalFromContext(ctx context.Context) principal {
+	p, _ := ctx.Value(principalKey{}).(principal)
+	return p
+}
+
+// jwtAuth проверяет JWT пользователя для сервисов, которые вызывают люди и их
+// инструменты, а не внутренние сервисы. Методы из public доступны без токена,
+// остальные — только ролям из roles; метода нет в roles — вызов отклоняется.
+type jwtAuth struct {
+	secretKey string
+	services  map[string]bool
+	public    map[string]bool
+	roles     map[string][]structures.Role
+}
+
+// pilotScoped — запрос, который может относиться к другому пилоту. Чужой
+// pilot_id разрешен только диспетчеру.
+type pilotScoped interface {
+	GetPilotId() int32
+}
+
+func (a *jwtAuth) covers(fullMethod string) bool {
+	return a.services[grpcServiceName(fullMethod)]
+}
+
+func (a *jwtAuth) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if a.public[fullMethod] {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing auth header")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid auth format")
+	}
+
+	claims, err := validatetoken.ValidateToken(token, a.secretKey)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	userId, ok := claims["userId"].(float64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid userId in token")
+	}
+
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = string(structures.RolePilot)
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal{UserId: int(userId), Role: structures.Role(role)}), nil
+}
+
+// authorize сверяет роль пользователя с таблицей roles и, для запросов от
+// имени пилота, запрещает не диспетчерам обращаться к чужим данным.
+func (a *jwtAuth) authorize(ctx context.Context, fullMethod string, req interface{}) error {
+	if a.public[fullMethod] {
+		return nil
+	}
+
+	user := principalFromContext(ctx)
+	if !slices.Contains(a.roles[fullMethod], user.Role) {
+		return status.Errorf(codes.PermissionDenied, "role %q cannot call %s", user.Role, fullMethod)
+	}
+
+	if scoped, ok := req.(pilotScoped); ok && user.Role != structures.RoleDispatcher {
+		if pilotId := int(scoped.GetPilotId()); pilotId != 0 && pilotId != user.UserId {
+			return status.Error(codes.PermissionDenied, "only dispatchers can access other pilots' data")
+		}
+	}
+
+	return nil
+}
+
+func (a *jwtAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !a.covers(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err == nil {
+		err = a.authorize(ctx, info.FullMethod, req)
+	}
+	if err != nil {
+		log.Printf("Rejected gRPC call %s: %v", info.FullMethod, err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *jwtAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !a.covers(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err == nil {
+		err = a.authorize(ctx, info.FullMethod, nil)
+	}
+	if err != nil {
+		log.Printf("Rejected gRPC stream %s: %v", info.FullMethod, err)
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// grpcServiceName выделяет package.Service из /package.Service/Method.
+func grpcServiceName(fullMethod string) string {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
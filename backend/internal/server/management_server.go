@@ -0,0 +1,428 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"github.com/nxbodyevzncvre/decenthack/internal/listing"
+	"github.com/nxbodyevzncvre/decenthack/internal/repository"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	generatetoken "github.com/nxbodyevzncvre/decenthack/pkg/jwt/generateToken"
+	managementv1 "github.com/nxbodyevzncvre/decenthack/proto/management/v1"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ManagementServer — gRPC-версия REST API поверх тех же репозиториев.
+type ManagementServer struct {
+	managementv1.UnimplementedManagementServiceServer
+	pilotRepo       repository.PilotRepository
+	droneRepo       repository.DroneRepository
+	applicationRepo repository.ApplicationRepository
+	zonesRepo       repository.ZonesRepository
+	cfg             config.Config
+}
+
+func NewManagementServer(
+	pilotRepo repository.PilotRepository,
+	droneRepo repository.DroneRepository,
+	applicationRepo repository.ApplicationRepository,
+	zonesRepo repository.ZonesRepository,
+	cfg config.Config,
+) *ManagementServer {
+	return &ManagementServer{
+		pilotRepo:       pilotRepo,
+		droneRepo:       droneRepo,
+		applicationRepo: applicationRepo,
+		zonesRepo:       zonesRepo,
+		cfg:             cfg,
+	}
+}
+
+var anyRole = []structures.Role{structures.RolePilot, structures.RoleDispatcher}
+
+// managementRoles — какие роли могут вызывать методы ManagementService.
+// Права совпадают с REST: пилоты управляют своими заявками, дронами и зонами.
+// Новый метод без записи здесь будет отклоняться интерцептором.
+var managementRoles = map[string][]structures.Role{
+	managementv1.ManagementService_GetPilot_FullMethodName:          anyRole,
+	managementv1.ManagementService_CreateDrone_FullMethodName:       anyRole,
+	managementv1.ManagementService_GetDrone_FullMethodName:          anyRole,
+	managementv1.ManagementService_ListDrones_FullMethodName:        anyRole,
+	managementv1.ManagementService_DeleteDrone_FullMethodName:       anyRole,
+	managementv1.ManagementService_CreateApplication_FullMethodName: anyRole,
+	managementv1.ManagementService_ListApplications_FullMethodName:  anyRole,
+	managementv1.ManagementService_DeleteApplication_FullMethodName: anyRole,
+	managementv1.ManagementService_CreateZone_FullMethodName:        anyRole,
+	managementv1.ManagementService_ListZones_FullMethodName:         anyRole,
+	managementv1.ManagementService_DeleteZone_FullMethodName:        anyRole,
+}
+
+// managementJWTAuth защищает ManagementService тем же JWT, что и REST.
+func managementJWTAuth(secretKey string) *jwtAuth {
+	return &jwtAuth{
+		secretKey: secretKey,
+		services:  map[string]bool{managementv1.ManagementService_ServiceDesc.ServiceName: true},
+		public: map[string]bool{
+			managementv1.ManagementService_SignIn_FullMethodName: true,
+			managementv1.ManagementService_SignUp_FullMethodName: true,
+		},
+		roles: managementRoles,
+	}
+}
+
+func (m *ManagementServer) SignIn(ctx context.Context, req *managementv1.SignInRequest) (*managementv1.TokenResponse, error) {
+	if req.Phone == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "phone and password are required")
+	}
+
+	pilot, err := m.pilotRepo.SelectPilot(req.Phone)
+	if err != nil {
+		log.Printf("Error selecting pilot: %v", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid phone or password")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(pilot.Password), []byte(req.Password)); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid phone or password")
+	}
+
+	accessToken, err := generatetoken.GenerateAccessToken(pilot.Id, string(pilot.Role), m.cfg.JWTSecretKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error with generating JWT")
+	}
+
+	return &managementv1.TokenResponse{AccessToken: accessToken}, nil
+}
+
+func (m *ManagementServer) SignUp(ctx context.Context, req *managementv1.SignUpRequest) (*managementv1.TokenResponse, error) {
+	if req.Firstname == "" || req.Lastname == "" || req.Middlename == "" || req.Password == "" || req.Phone == "" {
+		return nil, status.Error(codes.InvalidArgument, "all fields required")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error with hash")
+	}
+
+	pilotId, err := m.pilotRepo.InsertPilot(&structures.Pilot{
+		Firstname:  req.Firstname,
+		Lastname:   req.Lastname,
+		Middlename: req.Middlename,
+		Phone:      req.Phone,
+		Password:   string(hash),
+	})
+	if err != nil {
+		log.Printf("Error inserting pilot: %v", err)
+		return nil, status.Error(codes.Internal, "error with inserting data")
+	}
+
+	accessToken, err := generatetoken.GenerateAccessToken(pilotId, string(structures.RolePilot), m.cfg.JWTSecretKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error with generating JWT")
+	}
+
+	return &managementv1.TokenResponse{AccessToken: accessToken}, nil
+}
+
+func (m *ManagementServer) GetPilot(ctx context.Context, _ *emptypb.Empty) (*managementv1.Pilot, error) {
+	user := principalFromContext(ctx)
+
+	pilot, err := m.pilotRepo.SelectPilotById(user.UserId)
+	if err != nil {
+		return nil, repositoryError("pilot", err)
+	}
+
+	return &managementv1.Pilot{
+		PilotId:    int32(user.UserId),
+		Firstname:  pilot.Firstname,
+		Lastname:   pilot.Lastname,
+		Middlename: pilot.Middlename,
+		Phone:      pilot.Phone,
+		Role:       string(pilot.Role),
+	}, nil
+}
+
+func (m *ManagementServer) CreateDrone(ctx context.Context, req *managementv1.CreateDroneRequest) (*emptypb.Empty, error) {
+	if req.BrandName == "" || req.ModelName == "" || req.SerialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "all fields required")
+	}
+
+	err := m.droneRepo.InsertDrone(&structures.Drone{
+		Serial_number: req.SerialNumber,
+		Model_name:    req.ModelName,
+		Brand_name:    req.BrandName,
+	})
+	if err != nil {
+		return nil, repositoryError("drone", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (m *ManagementServer) GetDrone(ctx context.Context, req *managementv1.GetDroneRequest) (*managementv1.Drone, error) {
+	drone, err := m.droneRepo.SelectDroneById(int(req.DroneId))
+	if err != nil {
+		return nil, repositoryError("drone", err)
+	}
+
+	return droneToProto(*drone), nil
+}
+
+func (m *ManagementServer) ListDrones(ctx context.Context, req *managementv1.ListDronesRequest) (*managementv1.ListDronesResponse, error) {
+	page, err := listing.ParsePage(listing.PageQuery{
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+		Sort:   req.Sort,
+		Order:  req.Order,
+	}, "id")
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	drones, err := m.droneRepo.SelectAllDrones(page)
+	if err != nil {
+		return nil, repositoryError("drones", err)
+	}
+
+	drones, next := listing.Paginate(page, drones, func(drone structures.Drone) (int, string) {
+		return drone.Id, ""
+	})
+
+	resp := &managementv1.ListDronesResponse{Drones: make([]*managementv1.Drone, 0, len(drones)), NextCursor: next}
+	for _, drone := range drones {
+		resp.Drones = append(resp.Drones, droneToProto(drone))
+	}
+	return resp, nil
+}
+
+func (m *ManagementServer) DeleteDrone(ctx context.Context, req *managementv1.DeleteDroneRequest) (*emptypb.Empty, error) {
+	if err := m.droneRepo.DeleteDrone(int(req.DroneId)); err != nil {
+		return nil, repositoryError("drone", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (m *ManagementServer) CreateApplication(ctx context.Context, req *managementv1.CreateApplicationRequest) (*emptypb.Empty, error) {
+	if req.StartDate == "" || req.EndDate == "" || req.DroneId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "start_date, end_date and drone_id are required")
+	}
+
+	tested := 0
+	if req.Tested {
+		tested = 1
+	}
+
+	err := m.applicationRepo.CreateApplication(structures.CreateApplicationRequest{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Status:    structures.StatusPending,
+		PilotId:   principalFromContext(ctx).UserId,
+		DroneId:   int(req.DroneId),
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Altitude:  req.Altitude,
+		Tested:    tested,
+	})
+	if err != nil {
+		return nil, repositoryError("application", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (m *ManagementServer) ListApplications(ctx context.Context, req *managementv1.ListApplicationsRequest) (*managementv1.ListApplicationsResponse, error) {
+	page, err := listing.ParsePage(listing.PageQuery{
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+		Sort:   req.Sort,
+		Order:  req.Order,
+	}, listing.ApplicationSorts...)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	filter, err := listing.ParseApplicationFilter(listing.ApplicationQuery{
+		Statuses: req.Status,
+		DroneId:  int(req.DroneId),
+		From:     req.From,
+		To:       req.To,
+	})
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	// Чужой pilot_id интерцептор пропускает только диспетчеру
+	filter.PilotId = principalFromContext(ctx).UserId
+	if req.PilotId != 0 {
+		filter.PilotId = int(req.PilotId)
+	}
+
+	applications, err := m.applicationRepo.AllPilotsAplications(filter, page)
+	if err != nil {
+		return nil, repositoryError("applications", err)
+	}
+
+	applications, next := listing.Paginate(page, applications, listing.ApplicationKey(page))
+
+	resp := &managementv1.ListApplicationsResponse{Applications: make([]*managementv1.Application, 0, len(applications)), NextCursor: next}
+	for _, application := range applications {
+		resp.Applications = append(resp.Applications, &managementv1.Application{
+			ApplicationId: int32(application.Id),
+			StartDate:     application.StartDate,
+			Status:        string(application.Status),
+			CreatedAt:     timestamppb.New(application.CreatedAt),
+			SerialNumber:  application.Serialnumber,
+			Latitude:      application.Latitude,
+			Longitude:     application.Longitude,
+			Altitude:      application.Altitude,
+		})
+	}
+	return resp, nil
+}
+
+func (m *ManagementServer) DeleteApplication(ctx context.Context, req *managementv1.DeleteApplicationRequest) (*emptypb.Empty, error) {
+	user := principalFromContext(ctx)
+
+	owner, err := m.applicationRepo.SelectApplicationPilot(int(req.ApplicationId))
+	if err != nil {
+		return nil, repositoryError("application", err)
+	}
+
+	if owner != user.UserId && user.Role != structures.RoleDispatcher {
+		return nil, status.Error(codes.PermissionDenied, "application belongs to another pilot")
+	}
+
+	if err := m.applicationRepo.DeleteApplication(int(req.ApplicationId)); err != nil {
+		return nil, repositoryError("application", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (m *ManagementServer) CreateZone(ctx context.Context, req *managementv1.CreateZoneRequest) (*managementv1.Zone, error) {
+	if req.Name == "" || req.Radius <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "name and positive radius are required")
+	}
+
+	zone := structures.RestrictedZone{
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Altitude:  req.Altitude,
+		Name:      req.Name,
+		Radius:    int(req.Radius),
+	}
+	if err := m.zonesRepo.InsertZone(&zone); err != nil {
+		return nil, repositoryError("zone", err)
+	}
+
+	return zoneToProto(zone), nil
+}
+
+func (m *ManagementServer) ListZones(ctx context.Context, req *managementv1.ListZonesRequest) (*managementv1.ListZonesResponse, error) {
+	page, err := listing.ParsePage(listing.PageQuery{
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+		Sort:   req.Sort,
+		Order:  req.Order,
+	}, "id")
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	var filter repository.ZoneFilter
+	if box := req.Bbox; box != nil {
+		filter.BoundingBox, err = listing.ValidateBoundingBox(repository.BoundingBox{
+			MinLatitude:  box.MinLatitude,
+			MinLongitude: box.MinLongitude,
+			MaxLatitude:  box.MaxLatitude,
+			MaxLongitude: box.MaxLongitude,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bbox: %v", err)
+		}
+	}
+
+	zones, err := m.zonesRepo.GetAllZones(filter, page)
+	if err != nil {
+		return nil, repositoryError("zones", err)
+	}
+
+	zones, next := listing.Paginate(page, zones, func(zone structures.RestrictedZone) (int, string) {
+		return zone.Id, ""
+	})
+
+	resp := &managementv1.ListZonesResponse{Zones: make([]*managementv1.Zone, 0, len(zones)), NextCursor: next}
+	for _, zone := range zones {
+		resp.Zones = append(resp.Zones, zoneToProto(zone))
+	}
+	return resp, nil
+}
+
+func (m *ManagementServer) DeleteZone(ctx context.Context, req *managementv1.DeleteZoneRequest) (*emptypb.Empty, error) {
+	if err := m.zonesRepo.DeleteZone(int(req.ZoneId)); err != nil {
+		return nil, repositoryError("zone", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// invalidArgument переводит ошибку разбора параметров списка в статус gRPC.
+// Поля называются так же, как в REST, только без префикса query.
+func invalidArgument(err error) error {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	message := appErr.Message
+	for _, field := range appErr.Fields {
+		message += fmt.Sprintf("; %s %s", strings.TrimPrefix(field.Field, "query."), field.Message)
+	}
+	return status.Error(codes.InvalidArgument, message)
+}
+
+// repositoryError переводит ошибку репозитория в статус gRPC, не раскрывая
+// подробности базы клиенту.
+func repositoryError(entity string, err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "%s not found", entity)
+	}
+	if errors.Is(err, repository.ErrConflict) {
+		return status.Errorf(codes.AlreadyExists, "%s already exists", entity)
+	}
+	if errors.Is(err, repository.ErrInvalidState) {
+		return status.Errorf(codes.FailedPrecondition, "%s cannot be changed in its current state", entity)
+	}
+
+	log.Printf("Error with %s in database: %v", entity, err)
+	return status.Errorf(codes.Internal, "error with %s in database", entity)
+}
+
+func droneToProto(drone structures.Drone) *managementv1.Drone {
+	return &managementv1.Drone{
+		DroneId:      int32(drone.Id),
+		SerialNumber: drone.Serial_number,
+		ModelName:    drone.Model_name,
+		BrandName:    drone.Brand_name,
+	}
+}
+
+func zoneToProto(zone structures.RestrictedZone) *managementv1.Zone {
+	return &managementv1.Zone{
+		ZoneId:    int32(zone.Id),
+		Name:      zone.Name,
+		Latitude:  zone.Latitude,
+		Longitude: zone.Longitude,
+		Altitude:  zone.Altitude,
+		Radius:    int32(zone.Radius),
+	}
+}
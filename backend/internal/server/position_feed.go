@@ -0,0 +1,95 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	dronepb "github.com/nxbodyevzncvre/decenthack/pkg/pb"
+)
+
+// positionSubscriberBuffer — сколько координат ждет медленного подписчика.
+// При переполнении выбрасывается самая старая: важна текущая позиция.
+const positionSubscriberBuffer = 64
+
+// positionFeed раздает позиции из уведомлений процессора подписчикам
+// DroneService.SimulateFlight, минуя WebSocket-хаб.
+type positionFeed struct {
+	mutex       sync.Mutex
+	subscribers map[int]map[chan *dronepb.Coordinates]struct{}
+	last        map[int]*dronepb.Coordinates
+}
+
+func newPositionFeed() *positionFeed {
+	return &positionFeed{
+		subscribers: make(map[int]map[chan *dronepb.Coordinates]struct{}),
+		last:        make(map[int]*dronepb.Coordinates),
+	}
+}
+
+// subscribe возвращает канал координат заявки. Если позиция уже известна,
+// она приходит первой. Канал закрывается по завершении полета.
+func (f *positionFeed) subscribe(applicationId int) (<-chan *dronepb.Coordinates, func()) {
+	ch := make(chan *dronepb.Coordinates, positionSubscriberBuffer)
+
+	f.mutex.Lock()
+	if f.subscribers[applicationId] == nil {
+		f.subscribers[applicationId] = make(map[chan *dronepb.Coordinates]struct{})
+	}
+	f.subscribers[applicationId][ch] = struct{}{}
+	if last, ok := f.last[applicationId]; ok {
+		ch <- last
+	}
+	f.mutex.Unlock()
+
+	unsubscribe := func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		if subscribers, ok := f.subscribers[applicationId]; ok {
+			if _, ok := subscribers[ch]; ok {
+				delete(subscribers, ch)
+				close(ch)
+			}
+			if len(subscribers) == 0 {
+				delete(f.subscribers, applicationId)
+			}
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (f *positionFeed) publish(applicationId int, latitude, longitude, altitude float64, timestamp time.Time) {
+	coordinates := &dronepb.Coordinates{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Altitude:  altitude,
+		Timestamp: timestamp.Format(time.RFC3339Nano),
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.last[applicationId] = coordinates
+
+	for ch := range f.subscribers[applicationId] {
+		select {
+		case ch <- coordinates:
+		default:
+			<-ch
+			ch <- coordinates
+		}
+	}
+}
+
+// finish закрывает потоки заявки после завершения полета.
+func (f *positionFeed) finish(applicationId int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for ch := range f.subscribers[applicationId] {
+		close(ch)
+	}
+	delete(f.subscribers, applicationId)
+	delete(f.last, applicationId)
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"log"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/config"
+)
+
+type NotificationCleaner interface {
+	DeleteNotificationEventsBefore(before time.Time) (int64, error)
+	DeleteProcessedNotificationsBefore(before time.Time) (int64, error)
+}
+
+// RunNotificationCleanup раз в CleanupInterval удаляет события журнала и
+// отметки принятых уведомлений старше сроков хранения. Первая очистка —
+// сразу при запуске.
+func RunNotificationCleanup(store NotificationCleaner, retention config.Retention) {
+	ticker := time.NewTicker(retention.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		cleanupNotifications(store, retention, time.Now())
+		<-ticker.C
+	}
+}
+
+func cleanupNotifications(store NotificationCleaner, retention config.Retention, now time.Time) {
+	deleted, err := store.DeleteNotificationEventsBefore(now.Add(-retention.NotificationEvents))
+	if err != nil {
+		log.Printf("Failed to clean up notification events: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Removed %d notification events older than %v", deleted, retention.NotificationEvents)
+	}
+
+	deleted, err = store.DeleteProcessedNotificationsBefore(now.Add(-retention.ProcessedNotifications))
+	if err != nil {
+		log.Printf("Failed to clean up processed notifications: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Removed %d processed notifications older than %v", deleted, retention.ProcessedNotifications)
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type serviceKey struct{}
+
+// ServiceFromContext возвращает имя сервиса, чьим токеном подписан вызов.
+func ServiceFromContext(ctx context.Context) string {
+	service, _ := ctx.Value(serviceKey{}).(string)
+	return service
+}
+
+func serverOptions(cfg config.GRPC, users *jwtAuth) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		creds, err := serverCredentials(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else if !cfg.Insecure {
+		return nil, errors.New("grpc: cert_file and key_file are required unless insecure is set")
+	} else {
+		log.Println("WARNING: gRPC server is running without TLS")
+	}
+
+	if len(cfg.AuthTokens) == 0 {
+		return nil, errors.New("grpc: at least one auth token must be configured")
+	}
+
+	auth := newTokenAuth(cfg.AuthTokens, cfg.Permissions, users.services)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(auth.unary, users.unary),
+		grpc.ChainStreamInterceptor(auth.stream, users.stream),
+	)
+
+	return opts, nil
+}
+
+// serverCredentials загружает сертификат сервера. Если задан client_ca_file,
+// включается mTLS: клиент обязан предъявить сертификат, подписанный этим CA.
+func serverCredentials(cfg config.GRPC) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("grpc: failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("grpc: failed to read client CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("grpc: no certificates found in %s", cfg.ClientCAFile)
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		log.Println("gRPC server requires client certificates (mTLS)")
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// tokenAuth пропускает только вызовы с заголовком authorization: Bearer <token>,
+// где токен выдан одному из сервисов в конфигурации, и только к тем
+// gRPC-сервисам, которые ему разрешены. Сервисы из skip защищены jwtAuth.
+type tokenAuth struct {
+	tokens      map[string]string
+	permissions map[string]map[string]bool
+	skip        map[string]bool
+}
+
+func newTokenAuth(serviceTokens map[string]string, permissions map[string][]string, skip map[string]bool) *tokenAuth {
+	allowed := make(map[string]map[string]bool, len(permissions))
+	for service, grpcServices := range permissions {
+		allowed[service] = make(map[string]bool, len(grpcServices))
+		for _, grpcService := range grpcServices {
+			allowed[service][grpcService] = true
+		}
+	}
+
+	return &tokenAuth{tokens: serviceTokens, permissions: allowed, skip: skip}
+}
+
+func (a *tokenAuth) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcService := grpcServiceName(fullMethod)
+	service := ServiceFromContext(ctx)
+	if !a.permissions[service][grpcService] {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", service, grpcService)
+	}
+
+	return ctx, nil
+}
+
+func (a *tokenAuth) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization header")
+	}
+
+	for service, expected := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+			return context.WithValue(ctx, serviceKey{}, service), nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid authorization token")
+}
+
+func (a *tokenAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if a.skip[grpcServiceName(info.FullMethod)] {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		log.Printf("Rejected gRPC call %s: %v", info.FullMethod, err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *tokenAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a.skip[grpcServiceName(info.FullMethod)] {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		log.Printf("Rejected gRPC stream %s: %v", info.FullMethod, err)
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -5,39 +5,47 @@ import "time"
 type Status string
 
 const (
-	StatusPending  Status = "pending"
-	StatusApproved Status = "approved"
-	StatusRejected Status = "rejected"
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusApproved   Status = "approved"
+	StatusExecuting  Status = "executing"
+	StatusCompleted  Status = "completed"
+	StatusRejected   Status = "rejected"
+	StatusCancelled  Status = "cancelled"
 )
 
 type Application struct {
-	Id                    int       `json:"application_id"`
-	Start_date            string    `json:"start_date"`
-	End_date              string    `json"end_date"`
-	Status                Status    `json:"status"`
-	Rejection_reason      string    `json:"rejection_reason, omitempty"`
-	Restricted_zone_check int       `json:"restricted_zone_check, omitempty"`
-	Created_at            time.Time `json:"created_at, omitempty"`
-	Last_update           time.Time `json:"last_update, omitempty"`
-	Pilot_id              int       `json:"pilot_id"`
-	Drone_id              int       `json:"drone_id"`
+	Id                    int         `json:"application_id"`
+	Start_date            string      `json:"start_date"`
+	End_date              string      `json:"end_date"`
+	Status                Status      `json:"status"`
+	Rejection_reason      string      `json:"rejection_reason,omitempty"`
+	Restricted_zone_check int         `json:"restricted_zone_check,omitempty"`
+	Created_at            time.Time   `json:"created_at,omitempty"`
+	Last_update           time.Time   `json:"last_update,omitempty"`
+	Pilot_id              int         `json:"pilot_id"`
+	Drone_id              int         `json:"drone_id"`
+	Revision              int         `json:"revision"`
+	SeriesId              int         `json:"series_id,omitempty"`
+	MissionType           MissionType `json:"mission_type"`
 }
 
 type CreateApplicationRequest struct {
-	StartDate           string    `json:"start_date"`
-	EndDate             string    `json:"end_date"`
-	Status              Status    `json:"status"`
-	RejectionReason     string    `json:"rejection_reason,omitempty"`
-	RestrictedZoneCheck int       `json:"restricted_zone_check,omitempty"`
-	CreatedAt           time.Time `json:"created_at,omitempty"`
-	LastUpdate          time.Time `json:"last_update,omitempty"`
-	PilotId             int       `json:"pilot_id"`
-	DroneId             int       `json:"drone_id"`
-	Latitude            float64   `json:"latitude"`
-	Longtitude          float64   `json:"longtitude"`
-	Altitude            float64   `json:"altitude"`
-	PointOrder          int       `json:"point_order,omitempty"`
-	Tested              int       `json:"tested"`
+	StartDate           string           `json:"start_date"`
+	EndDate             string           `json:"end_date"`
+	Status              Status           `json:"status"`
+	RejectionReason     string           `json:"rejection_reason,omitempty"`
+	RestrictedZoneCheck int              `json:"restricted_zone_check,omitempty"`
+	CreatedAt           time.Time        `json:"created_at,omitempty"`
+	LastUpdate          time.Time        `json:"last_update,omitempty"`
+	PilotId             int              `json:"pilot_id"`
+	DroneId             int              `json:"drone_id"`
+	Latitude            float64          `json:"latitude"`
+	Longitude           float64          `json:"longitude"`
+	Altitude            float64          `json:"altitude"`
+	PointOrder          int              `json:"point_order,omitempty"`
+	Tested              int              `json:"tested"`
+	Actions             []WaypointAction `json:"actions,omitempty"`
 }
 
 type AllPitlotsApl struct {
@@ -47,6 +55,35 @@ type AllPitlotsApl struct {
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	Serialnumber string    `json:"serial_number"`
 	Latitude     float64   `json:"latitude"`
-	Longtitude   float64   `json:"longtitude"`
+	Longitude    float64   `json:"longitude"`
 	Altitude     float64   `json:"altitude"`
 }
+
+// ApplicationRevision — состояние заявки до очередного изменения. Revision —
+// номер, под которым это состояние было действующим.
+type ApplicationRevision struct {
+	Revision        int       `json:"revision"`
+	StartDate       string    `json:"start_date"`
+	EndDate         string    `json:"end_date"`
+	DroneId         int       `json:"drone_id"`
+	Status          Status    `json:"status"`
+	RejectionReason string    `json:"rejection_reason,omitempty"`
+	Route           []Routes  `json:"route"`
+	AmendedBy       int       `json:"amended_by"`
+	AmendedAt       time.Time `json:"amended_at"`
+}
+
+// FlightCommand — как процессор прекращает выполняемый полет.
+type FlightCommand string
+
+const (
+	// FlightCommandAbort — остановить полет на месте.
+	FlightCommandAbort FlightCommand = "abort"
+	// FlightCommandReturnHome — вернуть дрон в точку взлета.
+	FlightCommandReturnHome FlightCommand = "return_home"
+)
+
+type CancelApplicationRequest struct {
+	Reason  string        `json:"reason"`
+	Command FlightCommand `json:"command,omitempty"`
+}
@@ -0,0 +1,27 @@
+package structures
+
+import "time"
+
+// NotificationEvent — событие, разосланное подписчикам. Id монотонно растет
+// и служит клиентам точкой возобновления после переподключения.
+type NotificationEvent struct {
+	Id            int64     `json:"event_id"`
+	EventType     string    `json:"type"`
+	ApplicationId int       `json:"application_id"`
+	DroneId       int       `json:"drone_id"`
+	PilotId       int       `json:"pilot_id"`
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	HasPosition   bool      `json:"-"`
+	Payload       []byte    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ProcessedNotification фиксирует принятое по gRPC уведомление, чтобы
+// повторная доставка с тем же EventId не рассылалась заново.
+type ProcessedNotification struct {
+	EventId       string
+	EventType     string
+	ApplicationId int
+	CreatedAt     time.Time
+}
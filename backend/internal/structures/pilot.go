@@ -1,10 +1,18 @@
 package structures
 
+type Role string
+
+const (
+	RolePilot      Role = "pilot"
+	RoleDispatcher Role = "dispatcher"
+)
+
 type Pilot struct {
-	Id         int    `json:"pilot_id, omitempty"`
-	Firstname  string `json:"firstname, omitempty"`
-	Lastname   string `json:"lastname, omitempty"`
-	Middlename string `json:"middlename, omitempty"`
+	Id         int    `json:"pilot_id,omitempty"`
+	Firstname  string `json:"firstname,omitempty"`
+	Lastname   string `json:"lastname,omitempty"`
+	Middlename string `json:"middlename,omitempty"`
 	Phone      string `json:"phone"`
-	Password   string `json:"password"`
+	Password   string `json:"password,omitempty"`
+	Role       Role   `json:"role,omitempty"`
 }
@@ -1,10 +1,10 @@
 package structures
 
 type RestrictedZone struct {
-	Id         int     `json:"restrictedZone_id"`
-	Latitude   float64 `json:"latitude"`
-	Longtitude float64 `json:"longtitude"`
-	Altitude   float64 `json:"altitude"`
-	Name       string  `json:"zone_name"`
-	Radius     int     `json:"radius"`
+	Id        int     `json:"restrictedZone_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"`
+	Name      string  `json:"zone_name"`
+	Radius    int     `json:"radius"`
 }
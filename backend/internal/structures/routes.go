@@ -1,10 +1,31 @@
 package structures
 
 type Routes struct {
-	Id             int     `json:"route_id"`
-	Latitude       float64 `json:"latitude"`
-	Longtitude     float64 `json:"longtitude"`
-	Altitude       float64 `json:"altitude"`
-	Point_order    int     `json:"point_order, omitempty"`
-	Application_id int     `json:"application_id, omitempty"`
+	Id             int              `json:"route_id"`
+	Latitude       float64          `json:"latitude"`
+	Longitude      float64          `json:"longitude"`
+	Altitude       float64          `json:"altitude"`
+	Point_order    int              `json:"point_order,omitempty"`
+	Application_id int              `json:"application_id,omitempty"`
+	Actions        []WaypointAction `json:"actions,omitempty"`
+}
+
+type WaypointActionType string
+
+const (
+	ActionHover       WaypointActionType = "hover"
+	ActionOrbit       WaypointActionType = "orbit"
+	ActionPhoto       WaypointActionType = "photo"
+	ActionDropPayload WaypointActionType = "drop_payload"
+	ActionChangeSpeed WaypointActionType = "change_speed"
+)
+
+// WaypointAction — действие дрона по прибытии в точку маршрута. Seconds
+// нужен для hover, Radius и Turns — для orbit, Speed — для change_speed.
+type WaypointAction struct {
+	Type    WaypointActionType `json:"type"`
+	Seconds float64            `json:"seconds,omitempty"`
+	Radius  float64            `json:"radius,omitempty"`
+	Turns   float64            `json:"turns,omitempty"`
+	Speed   float64            `json:"speed,omitempty"`
 }
@@ -0,0 +1,43 @@
+package structures
+
+import "time"
+
+type SeriesStatus string
+
+const (
+	SeriesActive    SeriesStatus = "active"
+	SeriesCancelled SeriesStatus = "cancelled"
+)
+
+// CreateSeriesRequest — заявка с правилом повторения. StartDate и EndDate
+// задают первое вхождение, остальные получают то же время суток и ту же
+// длительность.
+type CreateSeriesRequest struct {
+	CreateApplicationRequest
+	RRule string `json:"rrule"`
+}
+
+// ApplicationSeries — серия регулярных полетов. Каждое вхождение — отдельная
+// заявка со своим маршрутом и статусом.
+type ApplicationSeries struct {
+	Id           int          `json:"series_id"`
+	PilotId      int          `json:"pilot_id"`
+	DroneId      int          `json:"drone_id"`
+	RRule        string       `json:"rrule"`
+	Status       SeriesStatus `json:"status"`
+	CancelReason string       `json:"cancel_reason,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Occurrences  []Occurrence `json:"occurrences"`
+}
+
+type Occurrence struct {
+	ApplicationId   int    `json:"application_id"`
+	StartDate       string `json:"start_date"`
+	EndDate         string `json:"end_date"`
+	Status          Status `json:"status"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+}
+
+type SeriesReasonRequest struct {
+	Reason string `json:"reason"`
+}
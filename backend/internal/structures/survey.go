@@ -0,0 +1,30 @@
+package structures
+
+// MissionType — как задан маршрут заявки.
+type MissionType string
+
+const (
+	// MissionPoint — пилот задал точку назначения.
+	MissionPoint MissionType = "point"
+	// MissionSurvey — маршрут построен облетом площади.
+	MissionSurvey MissionType = "survey"
+)
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// CreateSurveyRequest — заявка на съемку площади. Маршрут строится из
+// многоугольника, высоты, ширины полосы съемки и перекрытия.
+type CreateSurveyRequest struct {
+	StartDate      string     `json:"start_date"`
+	EndDate        string     `json:"end_date"`
+	PilotId        int        `json:"pilot_id"`
+	DroneId        int        `json:"drone_id"`
+	Tested         int        `json:"tested"`
+	Polygon        []GeoPoint `json:"polygon"`
+	Altitude       float64    `json:"altitude"`
+	FootprintWidth float64    `json:"footprint_width"`
+	Overlap        float64    `json:"overlap"`
+}
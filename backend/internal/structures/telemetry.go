@@ -0,0 +1,33 @@
+package structures
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type TrackPoint struct {
+	DroneId       int       `json:"drone_id"`
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	Altitude      float64   `json:"altitude"`
+	Speed         float64   `json:"speed"`
+	Heading       float64   `json:"heading"`
+	RouteProgress float64   `json:"route_progress"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// FlightEvent — запись истории полета. Details — поля события в JSON,
+// которые процессор сохраняет для воспроизведения.
+type FlightEvent struct {
+	Id            int64           `json:"event_id"`
+	ApplicationId int             `json:"application_id"`
+	DroneId       int             `json:"drone_id"`
+	EventType     string          `json:"event_type"`
+	AlertLevel    string          `json:"alert_level,omitempty"`
+	Message       string          `json:"message"`
+	Details       json.RawMessage `json:"details,omitempty"`
+	Latitude      float64         `json:"latitude"`
+	Longitude     float64         `json:"longitude"`
+	Altitude      float64         `json:"altitude"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -0,0 +1,192 @@
+// Package survey строит маршрут облета площади «змейкой»: параллельные
+// галсы через всю область с шагом, при котором полосы съемки соседних
+// галсов перекрываются на заданную долю.
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const earthRadius = 6371000.0
+
+// MaxWaypoints ограничивает размер маршрута: процессор проверяет каждую
+// точку и каждый участок по всем зонам.
+const MaxWaypoints = 500
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Mission struct {
+	Polygon []Point
+	// FootprintWidth — ширина полосы съемки на земле поперек галса, м
+	FootprintWidth float64
+	// Overlap — перекрытие соседних полос, доля от 0 до 0.9
+	Overlap float64
+}
+
+// Spacing — расстояние между соседними галсами.
+func (m Mission) Spacing() float64 {
+	return m.FootprintWidth * (1 - m.Overlap)
+}
+
+type vec struct{ x, y float64 }
+
+// Plan возвращает точки галсов по порядку облета. Галсы идут вдоль самой
+// длинной стороны многоугольника, это дает меньше разворотов. Для
+// невыпуклой области галс, пересекающий ее несколько раз, дает несколько
+// отрезков, перелет между ними может выходить за границу.
+func Plan(m Mission) ([]Point, error) {
+	if len(m.Polygon) < 3 {
+		return nil, errors.New("polygon must have at least 3 vertices")
+	}
+	if m.FootprintWidth <= 0 {
+		return nil, errors.New("footprint width must be positive")
+	}
+	if m.Overlap < 0 || m.Overlap > 0.9 {
+		return nil, errors.New("overlap must be between 0 and 0.9")
+	}
+
+	polygon := m.Polygon
+	if polygon[0] == polygon[len(polygon)-1] {
+		polygon = polygon[:len(polygon)-1]
+	}
+
+	origin := centroid(polygon)
+	local := make([]vec, len(polygon))
+	for i, p := range polygon {
+		local[i] = project(origin, p)
+	}
+
+	if math.Abs(area(local)) < 1 {
+		return nil, errors.New("polygon has no area")
+	}
+
+	angle := sweepAngle(local)
+	for i, v := range local {
+		local[i] = rotate(v, -angle)
+	}
+
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, v := range local {
+		minY = math.Min(minY, v.y)
+		maxY = math.Max(maxY, v.y)
+	}
+
+	spacing := m.Spacing()
+	var path []vec
+	reverse := false
+
+	// Первый галс на половине шага от края, чтобы полоса съемки доходила до границы
+	for y := minY + spacing/2; y < maxY; y += spacing {
+		xs := intersections(local, y)
+		if reverse {
+			for i, j := 0, len(xs)-1; i < j; i, j = i+1, j-1 {
+				xs[i], xs[j] = xs[j], xs[i]
+			}
+		}
+		for _, x := range xs {
+			path = append(path, vec{x, y})
+		}
+		if len(xs) > 0 {
+			reverse = !reverse
+		}
+
+		if len(path) > MaxWaypoints {
+			return nil, fmt.Errorf("area needs more than %d waypoints, increase footprint or reduce overlap", MaxWaypoints)
+		}
+	}
+
+	// Область уже одной полосы — один галс посередине
+	if len(path) == 0 {
+		xs := intersections(local, (minY+maxY)/2)
+		for _, x := range xs {
+			path = append(path, vec{x, (minY + maxY) / 2})
+		}
+	}
+
+	result := make([]Point, len(path))
+	for i, v := range path {
+		result[i] = unproject(origin, rotate(v, angle))
+	}
+
+	return result, nil
+}
+
+// intersections возвращает точки пересечения горизонтали y с границей,
+// парами вход-выход слева направо.
+func intersections(polygon []vec, y float64) []float64 {
+	var xs []float64
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		if (a.y <= y && b.y > y) || (b.y <= y && a.y > y) {
+			xs = append(xs, a.x+(y-a.y)*(b.x-a.x)/(b.y-a.y))
+		}
+	}
+	sort.Float64s(xs)
+
+	return xs
+}
+
+func sweepAngle(polygon []vec) float64 {
+	longest, angle := 0.0, 0.0
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		if length := math.Hypot(b.x-a.x, b.y-a.y); length > longest {
+			longest = length
+			angle = math.Atan2(b.y-a.y, b.x-a.x)
+		}
+	}
+
+	return angle
+}
+
+func rotate(v vec, angle float64) vec {
+	sin, cos := math.Sincos(angle)
+	return vec{v.x*cos - v.y*sin, v.x*sin + v.y*cos}
+}
+
+func area(polygon []vec) float64 {
+	sum := 0.0
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		sum += a.x*b.y - b.x*a.y
+	}
+
+	return sum / 2
+}
+
+func centroid(polygon []Point) Point {
+	var c Point
+	for _, p := range polygon {
+		c.Latitude += p.Latitude
+		c.Longitude += p.Longitude
+	}
+	c.Latitude /= float64(len(polygon))
+	c.Longitude /= float64(len(polygon))
+
+	return c
+}
+
+// project и unproject — равнопромежуточная проекция вокруг центра области.
+// Для участков в единицы километров ее ошибка меньше точности GPS.
+func project(origin, p Point) vec {
+	return vec{
+		x: radians(p.Longitude-origin.Longitude) * math.Cos(radians(origin.Latitude)) * earthRadius,
+		y: radians(p.Latitude-origin.Latitude) * earthRadius,
+	}
+}
+
+func unproject(origin Point, v vec) Point {
+	return Point{
+		Latitude:  origin.Latitude + degrees(v.y/earthRadius),
+		Longitude: origin.Longitude + degrees(v.x/(earthRadius*math.Cos(radians(origin.Latitude)))),
+	}
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
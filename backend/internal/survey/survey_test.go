@@ -0,0 +1,189 @@
+package survey
+
+import (
+	"math"
+	"testing"
+)
+
+var testOrigin = Point{Latitude: 51.15545, Longitude: 71.41216}
+
+// polygonOf строит многоугольник из смещений в метрах от testOrigin.
+func polygonOf(vertices ...vec) []Point {
+	polygon := make([]Point, len(vertices))
+	for i, v := range vertices {
+		polygon[i] = unproject(testOrigin, v)
+	}
+	return polygon
+}
+
+func rotated(angle float64, vertices ...vec) []vec {
+	result := make([]vec, len(vertices))
+	for i, v := range vertices {
+		result[i] = rotate(v, angle)
+	}
+	return result
+}
+
+// lanes раскладывает точки маршрута по галсам: соседние точки с одинаковой
+// координатой поперек галсов относятся к одному галсу.
+func lanes(t *testing.T, route []Point, angle float64) [][]vec {
+	t.Helper()
+
+	var result [][]vec
+	for _, p := range route {
+		v := rotate(project(testOrigin, p), -angle)
+		if n := len(result); n > 0 && math.Abs(result[n-1][0].y-v.y) < 1e-3 {
+			result[n-1] = append(result[n-1], v)
+			continue
+		}
+		result = append(result, []vec{v})
+	}
+	return result
+}
+
+var rectangle = []vec{{-200, -50}, {200, -50}, {200, 50}, {-200, 50}}
+
+// horseshoe — невыпуклая подкова: внизу сплошная полоса, выше y=-10 два рукава
+var horseshoe = []vec{{-100, -50}, {100, -50}, {100, 50}, {50, 50}, {50, -10}, {-50, -10}, {-50, 50}, {-100, 50}}
+
+func TestPlanLanes(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon []vec
+		angle   float64
+		lanes   int
+		points  int
+	}{
+		{"rectangle", rectangle, 0, 10, 20},
+		{"rotated rectangle", rotated(math.Pi/6, rectangle...), math.Pi / 6, 10, 20},
+		{"concave", horseshoe, 0, 10, 32},
+	}
+
+	for _, tt := range tests {
+		route, err := Plan(Mission{Polygon: polygonOf(tt.polygon...), FootprintWidth: 20, Overlap: 0.5})
+		if err != nil {
+			t.Fatalf("%s: Plan: %v", tt.name, err)
+		}
+
+		if len(route) != tt.points {
+			t.Errorf("%s: got %d waypoints, want %d", tt.name, len(route), tt.points)
+		}
+
+		got := lanes(t, route, tt.angle)
+		if len(got) != tt.lanes {
+			t.Fatalf("%s: got %d lanes, want %d", tt.name, len(got), tt.lanes)
+		}
+
+		for i, lane := range got {
+			// Первый галс на половине шага от края, дальше ровно через шаг
+			if want := -50 + 5 + 10*float64(i); math.Abs(lane[0].y-want) > 1e-3 {
+				t.Errorf("%s: lane %d at %.3f m, want %.3f m", tt.name, i, lane[0].y, want)
+			}
+
+			// Змейка: четные галсы идут в одну сторону, нечетные — обратно
+			forward := lane[len(lane)-1].x > lane[0].x
+			if forward != (i%2 == 0) {
+				t.Errorf("%s: lane %d flies the wrong way", tt.name, i)
+			}
+		}
+	}
+}
+
+func TestPlanConcaveLaneSplitsIntoSegments(t *testing.T) {
+	route, err := Plan(Mission{Polygon: polygonOf(horseshoe...), FootprintWidth: 20, Overlap: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Галс y=5 пересекает оба рукава: вход и выход в каждом
+	lane := lanes(t, route, 0)[5]
+	want := []float64{100, 50, -50, -100}
+	if lane[0].x < lane[len(lane)-1].x {
+		want = []float64{-100, -50, 50, 100}
+	}
+	if len(lane) != len(want) {
+		t.Fatalf("got %d points on lane, want %d", len(lane), len(want))
+	}
+	for i, x := range want {
+		if math.Abs(lane[i].x-x) > 1e-3 {
+			t.Errorf("point %d at x=%.3f m, want %.3f m", i, lane[i].x, x)
+		}
+	}
+}
+
+func TestPlanNarrowAreaGetsSingleLane(t *testing.T) {
+	strip := []vec{{-200, -2}, {200, -2}, {200, 2}, {-200, 2}}
+
+	route, err := Plan(Mission{Polygon: polygonOf(strip...), FootprintWidth: 20, Overlap: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := lanes(t, route, 0)
+	if len(got) != 1 || len(got[0]) != 2 {
+		t.Fatalf("got lanes %v, want one lane with two points", got)
+	}
+	if math.Abs(got[0][0].y) > 1e-3 {
+		t.Errorf("lane at %.3f m, want the middle of the strip", got[0][0].y)
+	}
+}
+
+func TestPlanClosedPolygon(t *testing.T) {
+	open, err := Plan(Mission{Polygon: polygonOf(rectangle...), FootprintWidth: 20, Overlap: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed, err := Plan(Mission{Polygon: polygonOf(append(rectangle, rectangle[0])...), FootprintWidth: 20, Overlap: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(open) != len(closed) {
+		t.Errorf("closed polygon gives %d waypoints, open gives %d", len(closed), len(open))
+	}
+}
+
+func TestPlanRejectsInvalidMissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		mission Mission
+	}{
+		{"two vertices", Mission{Polygon: polygonOf(vec{0, 0}, vec{100, 0}), FootprintWidth: 20}},
+		{"no area", Mission{Polygon: polygonOf(vec{0, 0}, vec{100, 0}, vec{200, 0}), FootprintWidth: 20}},
+		{"zero footprint", Mission{Polygon: polygonOf(rectangle...)}},
+		{"negative overlap", Mission{Polygon: polygonOf(rectangle...), FootprintWidth: 20, Overlap: -0.1}},
+		{"overlap above limit", Mission{Polygon: polygonOf(rectangle...), FootprintWidth: 20, Overlap: 0.95}},
+		// 10 км с шагом 10 м — тысяча галсов
+		{"too many waypoints", Mission{
+			Polygon:        polygonOf(vec{-5000, -5000}, vec{5000, -5000}, vec{5000, 5000}, vec{-5000, 5000}),
+			FootprintWidth: 20,
+			Overlap:        0.5,
+		}},
+	}
+
+	for _, tt := range tests {
+		if route, err := Plan(tt.mission); err == nil {
+			t.Errorf("%s: Plan returned %d waypoints, want error", tt.name, len(route))
+		}
+	}
+}
+
+func TestPlanStaysWithinMaxWaypoints(t *testing.T) {
+	// 249 галсов по две точки — еще в пределах MaxWaypoints
+	square := []vec{{-1245, -1245}, {1245, -1245}, {1245, 1245}, {-1245, 1245}}
+
+	route, err := Plan(Mission{Polygon: polygonOf(square...), FootprintWidth: 20, Overlap: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(route) > MaxWaypoints {
+		t.Errorf("got %d waypoints, limit is %d", len(route), MaxWaypoints)
+	}
+}
+
+func TestSpacing(t *testing.T) {
+	if got := (Mission{FootprintWidth: 20, Overlap: 0.25}).Spacing(); got != 15 {
+		t.Errorf("Spacing = %v, want 15", got)
+	}
+}
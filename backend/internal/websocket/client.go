@@ -0,0 +1,302 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+const (
+	// sendQueueSize — сколько обычных сообщений может ждать отправки, прежде
+	// чем клиент будет признан медленным и отключен.
+	sendQueueSize = 256
+	// resumeBufferSize — сколько живых событий может накопиться, пока клиент
+	// догружает пропущенное после переподключения.
+	resumeBufferSize = 1024
+	writeWait        = 10 * time.Second
+	pongWait         = 60 * time.Second
+	pingPeriod       = pongWait * 9 / 10
+)
+
+var (
+	errClientClosed = errors.New("websocket client is closed")
+	errSlowClient   = errors.New("websocket client send queue is full")
+)
+
+// client владеет исходящей половиной соединения. Писать в сокет может только
+// горутина pump, остальные кладут сообщения в очередь и никогда не ждут.
+// Для SSE-клиентов conn равен nil, доставкой занимается HandleSSE.
+type client struct {
+	conn   *websocket.Conn
+	replay *replaySession
+
+	userId             int
+	role               structures.Role
+	subscriptions      []subscription
+	subscriptionsMutex sync.RWMutex
+
+	// binary — клиент запросил format=protobuf: в очередях лежат уже
+	// закодированные EventFrame.
+	binary bool
+
+	send chan []byte
+	done chan struct{}
+
+	// Позиции не копятся в очереди: для каждой заявки хранится только последняя,
+	// промежуточные точки медленному клиенту не нужны.
+	positions      map[int][]byte
+	positionOrder  []int
+	positionsMutex sync.Mutex
+	positionsReady chan struct{}
+
+	// deliveredThrough — номер последнего события, уже отданного клиенту при
+	// возобновлении; более старые события из живого потока пропускаются.
+	deliveredThrough int64
+
+	// Пока идет догрузка пропущенных событий, живые события копятся в
+	// resumeBuffer и уходят в send только после нее.
+	resuming     bool
+	resumeBuffer [][]byte
+	resumeMutex  sync.Mutex
+
+	closeOnce sync.Once
+}
+
+func newClient(conn *websocket.Conn, userId int, role structures.Role) *client {
+	return &client{
+		conn:           conn,
+		userId:         userId,
+		role:           role,
+		subscriptions:  []subscription{{Topic: TopicOwn}},
+		send:           make(chan []byte, sendQueueSize),
+		done:           make(chan struct{}),
+		positions:      make(map[int][]byte),
+		positionsReady: make(chan struct{}, 1),
+	}
+}
+
+// encode переводит JSON-сообщение в формат клиента.
+func (c *client) encode(message []byte) ([]byte, error) {
+	if !c.binary {
+		return message, nil
+	}
+	return events.EncodeFrame(message)
+}
+
+func (c *client) write(message []byte) error {
+	encoded, err := c.encode(message)
+	if err != nil {
+		return err
+	}
+	return c.enqueue(encoded)
+}
+
+// enqueue ставит уже закодированное сообщение в очередь. Переполненная очередь
+// означает, что клиент не успевает читать, и он отключается, а не тормозит остальных.
+func (c *client) enqueue(message []byte) error {
+	select {
+	case <-c.done:
+		return errClientClosed
+	default:
+	}
+
+	c.resumeMutex.Lock()
+	if c.resuming {
+		defer c.resumeMutex.Unlock()
+		if len(c.resumeBuffer) >= resumeBufferSize {
+			c.close()
+			return errSlowClient
+		}
+		c.resumeBuffer = append(c.resumeBuffer, message)
+		return nil
+	}
+	c.resumeMutex.Unlock()
+
+	select {
+	case c.send <- message:
+		return nil
+	default:
+		c.close()
+		return errSlowClient
+	}
+}
+
+// writeWaiting ждет места в очереди не дольше timeout. Используется при
+// догрузке пропущенных событий, которых может быть больше размера очереди.
+func (c *client) writeWaiting(message []byte, timeout time.Duration) error {
+	message, err := c.encode(message)
+	if err != nil {
+		return err
+	}
+	return c.enqueueWaiting(message, timeout)
+}
+
+func (c *client) enqueueWaiting(message []byte, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case c.send <- message:
+		return nil
+	case <-c.done:
+		return errClientClosed
+	case <-timer.C:
+		c.close()
+		return errSlowClient
+	}
+}
+
+func (c *client) beginResume() {
+	c.resumeMutex.Lock()
+	c.resuming = true
+	c.resumeMutex.Unlock()
+}
+
+// finishResume переносит накопленные за время догрузки события в очередь
+// отправки. Флаг снимается только на пустом буфере, поэтому событие,
+// пришедшее во время переноса, не обгонит более ранние.
+func (c *client) finishResume() error {
+	for {
+		c.resumeMutex.Lock()
+		buffered := c.resumeBuffer
+		c.resumeBuffer = nil
+		if len(buffered) == 0 {
+			c.resuming = false
+			c.resumeMutex.Unlock()
+			return nil
+		}
+		c.resumeMutex.Unlock()
+
+		for _, message := range buffered {
+			if err := c.enqueueWaiting(message, writeWait); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *client) writeJSON(data interface{}) error {
+	message, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return c.write(message)
+}
+
+// enqueuePosition заменяет еще не отправленную позицию той же заявки.
+func (c *client) enqueuePosition(applicationId int, message []byte) error {
+	select {
+	case <-c.done:
+		return errClientClosed
+	default:
+	}
+
+	c.positionsMutex.Lock()
+	if _, pending := c.positions[applicationId]; !pending {
+		c.positionOrder = append(c.positionOrder, applicationId)
+	}
+	c.positions[applicationId] = message
+	c.positionsMutex.Unlock()
+
+	select {
+	case c.positionsReady <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (c *client) takePositions() [][]byte {
+	c.positionsMutex.Lock()
+	defer c.positionsMutex.Unlock()
+
+	if len(c.positionOrder) == 0 {
+		return nil
+	}
+
+	messages := make([][]byte, 0, len(c.positionOrder))
+	for _, applicationId := range c.positionOrder {
+		messages = append(messages, c.positions[applicationId])
+		delete(c.positions, applicationId)
+	}
+	c.positionOrder = c.positionOrder[:0]
+	return messages
+}
+
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *client) writeFrame(messageType int, message []byte) error {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.conn.WriteMessage(messageType, message)
+}
+
+// flushPositions отправляет накопленные позиции. Вызывается и перед обычными
+// сообщениями, чтобы позиция не пришла после, например, завершения полета.
+func (c *client) flushPositions(deliver func([]byte) error) error {
+	for _, message := range c.takePositions() {
+		if err := deliver(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pump разбирает очереди клиента, пока не закроется клиент или не упадет
+// запись; keepalive вызывается каждые pingPeriod.
+func (c *client) pump(deliver func([]byte) error, keepalive func() error) {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.close()
+	}()
+
+	for {
+		select {
+		case message := <-c.send:
+			if err := c.flushPositions(deliver); err != nil {
+				return
+			}
+			if err := deliver(message); err != nil {
+				return
+			}
+		case <-c.positionsReady:
+			if err := c.flushPositions(deliver); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := keepalive(); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *client) writePump() {
+	// Закрытие соединения прерывает ReadMessage в HandleWebSocket,
+	// после чего клиент снимается с регистрации
+	defer c.conn.Close()
+
+	messageType := websocket.TextMessage
+	if c.binary {
+		messageType = websocket.BinaryMessage
+	}
+
+	c.pump(
+		func(message []byte) error {
+			return c.writeFrame(messageType, message)
+		},
+		func() error {
+			return c.writeFrame(websocket.PingMessage, nil)
+		},
+	)
+}
@@ -0,0 +1,152 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// resumeMaxEvents ограничивает догрузку пропущенных событий. Если разрыв
+// больше, клиент получает truncated и должен перечитать состояние через REST.
+const resumeMaxEvents = 1000
+
+type EventLog interface {
+	InsertNotificationEvent(event *structures.NotificationEvent) error
+	SelectNotificationEventsAfter(afterId int64, limit int) ([]structures.NotificationEvent, error)
+}
+
+// persist сохраняет событие и возвращает сообщение с event_id. Вызывается под
+// publishMutex, иначе событие с меньшим номером могло бы уйти в рассылку позже
+// большего, и клиент, переподключившийся между ними, потерял бы его.
+func (h *Hub) persist(meta EventMeta, payload []byte) (int64, []byte, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(payload, &header)
+
+	event := &structures.NotificationEvent{
+		EventType:     header.Type,
+		ApplicationId: meta.ApplicationId,
+		DroneId:       meta.DroneId,
+		PilotId:       meta.PilotId,
+		Latitude:      meta.Latitude,
+		Longitude:     meta.Longitude,
+		HasPosition:   meta.HasPosition,
+		Payload:       payload,
+	}
+
+	if err := h.events.InsertNotificationEvent(event); err != nil {
+		return 0, payload, err
+	}
+
+	return event.Id, withEventId(payload, event.Id), nil
+}
+
+func withEventId(payload []byte, eventId int64) []byte {
+	if !bytes.HasPrefix(payload, []byte("{")) {
+		return payload
+	}
+
+	message := make([]byte, 0, len(payload)+32)
+	message = append(message, `{"event_id":`...)
+	message = strconv.AppendInt(message, eventId, 10)
+	if len(payload) > 2 {
+		message = append(message, ',')
+	}
+	return append(message, payload[1:]...)
+}
+
+func eventMetaOf(event structures.NotificationEvent) EventMeta {
+	return EventMeta{
+		ApplicationId: event.ApplicationId,
+		DroneId:       event.DroneId,
+		PilotId:       event.PilotId,
+		Latitude:      event.Latitude,
+		Longitude:     event.Longitude,
+		HasPosition:   event.HasPosition,
+	}
+}
+
+// missedEvents возвращает события после lastEventId, которые клиент получил бы
+// по своим подпискам, и номер последнего просмотренного события.
+func (h *Hub) missedEvents(cl *client, lastEventId int64) ([][]byte, int64, bool, error) {
+	stored, err := h.events.SelectNotificationEventsAfter(lastEventId, resumeMaxEvents+1)
+	if err != nil {
+		return nil, lastEventId, false, err
+	}
+
+	truncated := len(stored) > resumeMaxEvents
+	if truncated {
+		stored = stored[:resumeMaxEvents]
+	}
+
+	through := lastEventId
+	messages := make([][]byte, 0, len(stored))
+	for _, event := range stored {
+		through = event.Id
+		meta := eventMetaOf(event)
+		if cl.receives(&meta) {
+			messages = append(messages, withEventId(event.Payload, event.Id))
+		}
+	}
+
+	return messages, through, truncated, nil
+}
+
+// registerClient подключает клиента к рассылке и возвращается сразу после
+// регистрации, поэтому к моменту снятия с регистрации клиент уже в хабе.
+// При возобновлении снимок пропущенных событий берется под publishMutex,
+// чтобы между ним и живым потоком не было дыры, а повторы отсекались по
+// номеру события. Саму догрузку выполняет возвращенная функция, и вызывать
+// ее нужно, когда очередь клиента уже разбирается: живые события тем
+// временем копятся в буфере клиента, и публикация для остальных не ждет.
+func (h *Hub) registerClient(cl *client, lastEventId int64) (resume func()) {
+	if h.events == nil || lastEventId <= 0 {
+		h.register <- cl
+		h.welcome(cl)
+		return func() {}
+	}
+
+	h.publishMutex.Lock()
+	defer h.publishMutex.Unlock()
+
+	missed, through, truncated, err := h.missedEvents(cl, lastEventId)
+	if err != nil {
+		log.Printf("Error loading missed events after %d: %v", lastEventId, err)
+	}
+	cl.deliveredThrough = through
+
+	h.welcome(cl)
+	cl.beginResume()
+	h.register <- cl
+
+	return func() {
+		h.deliverMissed(cl, missed, through, truncated || err != nil)
+	}
+}
+
+func (h *Hub) deliverMissed(cl *client, missed [][]byte, through int64, truncated bool) {
+	for _, message := range missed {
+		if err := cl.writeWaiting(message, writeWait); err != nil {
+			return
+		}
+	}
+
+	complete, _ := json.Marshal(events.ResumeComplete{
+		Header:      events.NewHeader(events.TypeResumeComplete),
+		LastEventId: through,
+		Replayed:    len(missed),
+		Truncated:   truncated,
+		Timestamp:   time.Now(),
+	})
+	if err := cl.writeWaiting(complete, writeWait); err != nil {
+		return
+	}
+
+	cl.finishResume()
+}
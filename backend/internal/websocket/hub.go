@@ -2,81 +2,153 @@ package websocket
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+	validatetoken "github.com/nxbodyevzncvre/decenthack/pkg/jwt/validateToken"
 )
 
+type FlightSource interface {
+	SelectApplicationPilot(id int) (int, error)
+	SelectTrack(applicationId int, from, to time.Time) ([]structures.TrackPoint, error)
+	SelectFlightEvents(applicationId int) ([]structures.FlightEvent, error)
+}
+
+type outgoing struct {
+	meta    *EventMeta
+	eventId int64
+	message []byte
+}
+
+const broadcastQueueSize = 1024
+
 type Hub struct {
-	clients    map[*websocket.Conn]bool
-	broadcast  chan []byte
-	register   chan *websocket.Conn
-	unregister chan *websocket.Conn
+	clients    map[*client]bool
+	broadcast  chan outgoing
+	register   chan *client
+	unregister chan *client
 	mutex      sync.RWMutex
+	flights    FlightSource
+	events     EventLog
+
+	publishMutex sync.Mutex
+
+	owners      map[int]int
+	ownersMutex sync.RWMutex
 }
 
-func NewHub() *Hub {
+func NewHub(flights FlightSource, events EventLog) *Hub {
 	return &Hub{
-		clients:    make(map[*websocket.Conn]bool),
-		broadcast:  make(chan []byte),
-		register:   make(chan *websocket.Conn),
-		unregister: make(chan *websocket.Conn),
+		clients:    make(map[*client]bool),
+		broadcast:  make(chan outgoing, broadcastQueueSize),
+		register:   make(chan *client),
+		unregister: make(chan *client),
+		flights:    flights,
+		events:     events,
+		owners:     make(map[int]int),
 	}
 }
 
 func (h *Hub) Run() {
 	for {
 		select {
-		case conn := <-h.register:
+		case cl := <-h.register:
 			h.mutex.Lock()
-			h.clients[conn] = true
+			h.clients[cl] = true
+			total := len(h.clients)
 			h.mutex.Unlock()
 
-			welcomeMsg := map[string]interface{}{
-				"type":      "connection",
-				"message":   "WebSocket connected successfully",
-				"timestamp": time.Now(),
-				"clients":   len(h.clients),
-			}
-			if data, err := json.Marshal(welcomeMsg); err == nil {
-				conn.WriteMessage(websocket.TextMessage, data)
-			}
-
-			log.Printf("WebSocket client connected. Total clients: %d", len(h.clients))
+			log.Printf("WebSocket client connected (user %d, %s). Total clients: %d", cl.userId, cl.role, total)
 
-		case conn := <-h.unregister:
+		case cl := <-h.unregister:
 			h.mutex.Lock()
-			if _, ok := h.clients[conn]; ok {
-				delete(h.clients, conn)
-				conn.Close()
+			if h.clients[cl] {
+				delete(h.clients, cl)
+				cl.close()
 			}
+			total := len(h.clients)
 			h.mutex.Unlock()
-			log.Printf("WebSocket client disconnected. Total clients: %d", len(h.clients))
-
-		case message := <-h.broadcast:
-			h.mutex.RLock()
-			for conn := range h.clients {
-				err := conn.WriteMessage(websocket.TextMessage, message)
-				if err != nil {
-					log.Printf("Error writing to WebSocket: %v", err)
-					delete(h.clients, conn)
-					conn.Close()
-				}
+			log.Printf("WebSocket client disconnected. Total clients: %d", total)
+
+		case out := <-h.broadcast:
+			h.dispatch(out)
+		}
+	}
+}
+
+// dispatch раскладывает сообщение по очередям клиентов и никогда не пишет
+// в сокет сам, поэтому медленный клиент не задерживает рассылку остальным.
+func (h *Hub) dispatch(out outgoing) {
+	var evicted []*client
+
+	// Бинарный кадр кодируется один раз на сообщение, а не на каждого клиента
+	var frame []byte
+	var frameErr error
+	encodeFrame := func() ([]byte, error) {
+		if frame == nil && frameErr == nil {
+			frame, frameErr = events.EncodeFrame(out.message)
+		}
+		return frame, frameErr
+	}
+
+	h.mutex.RLock()
+	for cl := range h.clients {
+		if out.eventId != 0 && out.eventId <= cl.deliveredThrough {
+			continue
+		}
+		if !cl.receives(out.meta) {
+			continue
+		}
+
+		payload := out.message
+		if cl.binary {
+			var err error
+			if payload, err = encodeFrame(); err != nil {
+				log.Printf("Error encoding binary event frame: %v", err)
+				continue
 			}
-			h.mutex.RUnlock()
 		}
+
+		var err error
+		if out.meta != nil && out.meta.Coalesce {
+			err = cl.enqueuePosition(out.meta.ApplicationId, payload)
+		} else {
+			err = cl.enqueue(payload)
+		}
+
+		if err != nil {
+			evicted = append(evicted, cl)
+		}
+	}
+	h.mutex.RUnlock()
+
+	if len(evicted) == 0 {
+		return
 	}
+
+	h.mutex.Lock()
+	for _, cl := range evicted {
+		if h.clients[cl] {
+			delete(h.clients, cl)
+			log.Printf("Evicting slow WebSocket client (user %d): send queue is full", cl.userId)
+		}
+	}
+	h.mutex.Unlock()
 }
 
+// Broadcast отправляет системное сообщение всем подключенным клиентам.
+// События полетов должны идти через Publish.
 func (h *Hub) Broadcast(message []byte) {
-	select {
-	case h.broadcast <- message:
-	default:
-		log.Println("Broadcast channel is full, dropping message")
-	}
+	h.broadcast <- outgoing{message: message}
 }
 
 func (h *Hub) BroadcastJSON(data interface{}) error {
@@ -88,15 +160,114 @@ func (h *Hub) BroadcastJSON(data interface{}) error {
 	return nil
 }
 
+// Publish доставляет событие полета только клиентам, чьи подписки ему соответствуют.
+func (h *Hub) Publish(meta EventMeta, data interface{}) error {
+	message, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if meta.PilotId != 0 {
+		h.rememberApplicationPilot(meta.ApplicationId, meta.PilotId)
+	} else {
+		meta.PilotId = h.applicationPilot(meta.ApplicationId)
+	}
+
+	// Позиции не журналируются: они идут часто, сохраняются в drone_telemetry
+	// и после переподключения все равно заменяются следующей точкой
+	if meta.Coalesce || h.events == nil {
+		h.broadcast <- outgoing{meta: &meta, message: message}
+		return nil
+	}
+
+	h.publishMutex.Lock()
+	defer h.publishMutex.Unlock()
+
+	// Не сохраненное событие не рассылается: вызов завершится ошибкой, и
+	// процессор повторит его, а иначе событие не попало бы в журнал
+	eventId, message, err := h.persist(meta, message)
+	if err != nil {
+		return fmt.Errorf("failed to persist notification event for application %d: %w", meta.ApplicationId, err)
+	}
+
+	h.broadcast <- outgoing{meta: &meta, eventId: eventId, message: message}
+	return nil
+}
+
+func (h *Hub) welcome(cl *client) {
+	h.mutex.RLock()
+	clients := len(h.clients)
+	h.mutex.RUnlock()
+
+	cl.writeJSON(events.Connection{
+		Header:        events.NewHeader(events.TypeConnection),
+		Message:       "Connected successfully",
+		Timestamp:     time.Now(),
+		Clients:       clients,
+		UserId:        cl.userId,
+		Role:          string(cl.role),
+		Subscriptions: cl.currentSubscriptions(),
+	})
+}
+
+func (h *Hub) rememberApplicationPilot(applicationId, pilotId int) {
+	h.ownersMutex.Lock()
+	h.owners[applicationId] = pilotId
+	h.ownersMutex.Unlock()
+}
+
+// applicationPilot возвращает владельца заявки; владелец не меняется,
+// поэтому результат кешируется и база опрашивается один раз на заявку.
+func (h *Hub) applicationPilot(applicationId int) int {
+	h.ownersMutex.RLock()
+	pilotId, ok := h.owners[applicationId]
+	h.ownersMutex.RUnlock()
+	if ok {
+		return pilotId
+	}
+
+	if h.flights == nil {
+		return 0
+	}
+
+	pilotId, err := h.flights.SelectApplicationPilot(applicationId)
+	if err != nil {
+		log.Printf("Error resolving pilot for application %d: %v", applicationId, err)
+		return 0
+	}
+
+	h.rememberApplicationPilot(applicationId, pilotId)
+	return pilotId
+}
+
 func (h *Hub) HandleWebSocket(c *websocket.Conn) {
 	remoteAddr := c.RemoteAddr().String()
 	log.Printf("New WebSocket connection from %s", remoteAddr)
 
-	h.register <- c
+	userId, _ := c.Locals("userId").(int)
+	role, _ := c.Locals("role").(string)
+
+	lastEventId, _ := strconv.ParseInt(c.Query("last_event_id"), 10, 64)
+
+	cl := newClient(c, userId, structures.Role(role))
+	cl.binary = c.Query("format") == "protobuf"
+	resume := h.registerClient(cl, lastEventId)
+	go cl.writePump()
+	resume()
+
+	// Браузер отвечает на ping из writePump; если pong не пришел за pongWait,
+	// ReadMessage вернет ошибку и соединение будет закрыто.
+	c.SetReadDeadline(time.Now().Add(pongWait))
+	c.SetPongHandler(func(string) error {
+		return c.SetReadDeadline(time.Now().Add(pongWait))
+	})
 
 	defer func() {
 		log.Printf("Closing WebSocket connection from %s", remoteAddr)
-		h.unregister <- c
+		if cl.replay != nil {
+			cl.replay.close()
+		}
+		h.unregister <- cl
 	}()
 
 	for {
@@ -112,18 +283,122 @@ func (h *Hub) HandleWebSocket(c *websocket.Conn) {
 
 		if messageType == websocket.TextMessage {
 			log.Printf("Received message from %s: %s", remoteAddr, string(message))
+			h.handleClientMessage(cl, message)
 		}
+	}
+}
 
-		if messageType == websocket.PingMessage {
-			c.WriteMessage(websocket.PongMessage, nil)
+func (h *Hub) handleClientMessage(cl *client, message []byte) {
+	var command struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(message, &command); err != nil {
+		return
+	}
+
+	switch command.Action {
+	case "subscribe", "unsubscribe":
+		var sub subscriptionCommand
+		if err := json.Unmarshal(message, &sub); err != nil {
+			return
+		}
+		h.handleSubscription(cl, sub)
+	case "subscriptions":
+		cl.writeJSON(events.Subscriptions{
+			Header:        events.NewHeader(events.TypeSubscriptions),
+			Subscriptions: cl.currentSubscriptions(),
+		})
+	case "replay_start", "replay_pause", "replay_resume", "replay_seek", "replay_speed", "replay_stop":
+		var replay replayCommand
+		if err := json.Unmarshal(message, &replay); err != nil {
+			return
 		}
+		h.handleReplay(cl, replay)
 	}
 }
 
-func WebSocketUpgrade(c *fiber.Ctx) error {
-	if websocket.IsWebSocketUpgrade(c) {
+func (h *Hub) handleSubscription(cl *client, command subscriptionCommand) {
+	if err := command.validate(cl.role); err != nil {
+		cl.writeJSON(events.SubscriptionError{
+			Header:  events.NewHeader(events.TypeSubscriptionError),
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if command.Action == "subscribe" {
+		cl.subscribe(command.subscription)
+	} else {
+		cl.unsubscribe(command.subscription)
+	}
+
+	cl.writeJSON(events.Subscriptions{
+		Header:        events.NewHeader(events.TypeSubscriptions),
+		Subscriptions: cl.currentSubscriptions(),
+	})
+}
+
+func (h *Hub) handleReplay(cl *client, command replayCommand) {
+	switch command.Action {
+	case "replay_start":
+		h.startReplay(cl, command)
+	case "replay_stop":
+		if cl.replay != nil {
+			cl.replay.close()
+			cl.replay = nil
+		}
+	default:
+		if cl.replay == nil {
+			cl.writeJSON(replayError(command.ApplicationId, "No replay in progress"))
+			return
+		}
+		cl.replay.send(command)
+	}
+}
+
+// WebSocketUpgrade пропускает апгрейд только с валидным JWT.
+func WebSocketUpgrade(secretKey string) fiber.Handler {
+	auth := StreamAuth(secretKey)
+
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		return auth(c)
+	}
+}
+
+// StreamAuth проверяет JWT для потоковых эндпоинтов. Ни WebSocket, ни EventSource
+// в браузере не умеют передавать заголовки, поэтому токен принимается и в query.
+func StreamAuth(secretKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := c.Query("token")
+		if header := c.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+			token = strings.TrimPrefix(header, "Bearer ")
+		}
+
+		if token == "" {
+			return apperror.Unauthorized("Missing auth token")
+		}
+
+		claims, err := validatetoken.ValidateToken(token, secretKey)
+		if err != nil {
+			return apperror.Unauthorized("Invalid token")
+		}
+
+		userId, ok := claims["userId"].(float64)
+		if !ok {
+			return apperror.Unauthorized("Invalid userId in token")
+		}
+
+		role, _ := claims["role"].(string)
+		if role == "" {
+			role = string(structures.RolePilot)
+		}
+
 		c.Locals("allowed", true)
+		c.Locals("userId", int(userId))
+		c.Locals("role", role)
 		return c.Next()
 	}
-	return fiber.ErrUpgradeRequired
 }
@@ -0,0 +1,142 @@
+package websocket
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+const benchmarkClients = 1000
+
+// TestDispatchEvictsSlowClient проверяет, что переполненная очередь отключает
+// только того, кто не читает: остальные получают все сообщения, включая то,
+// на котором медленный клиент был отключен.
+func TestDispatchEvictsSlowClient(t *testing.T) {
+	hub := NewHub(nil, nil)
+
+	slow := newClient(nil, 1, structures.RoleDispatcher)
+	slow.subscriptions = []subscription{{Topic: TopicAll}}
+
+	fast := newClient(nil, 2, structures.RoleDispatcher)
+	fast.subscriptions = []subscription{{Topic: TopicAll}}
+
+	hub.clients[slow] = true
+	hub.clients[fast] = true
+
+	status := outgoing{
+		meta:    &EventMeta{ApplicationId: 1, DroneId: 1},
+		message: []byte(`{"type":"status_update","application_id":1}`),
+	}
+
+	for i := 0; i <= sendQueueSize; i++ {
+		hub.dispatch(status)
+
+		select {
+		case <-fast.send:
+		default:
+			t.Fatalf("reading client missed message %d", i)
+		}
+
+		if i < sendQueueSize && !hub.clients[slow] {
+			t.Fatalf("slow client evicted after %d messages, queue holds %d", i+1, sendQueueSize)
+		}
+	}
+
+	if hub.clients[slow] {
+		t.Errorf("slow client is still registered after its queue overflowed")
+	}
+	select {
+	case <-slow.done:
+	default:
+		t.Errorf("evicted client was not closed")
+	}
+
+	if !hub.clients[fast] {
+		t.Errorf("reading client was evicted")
+	}
+
+	hub.dispatch(status)
+	if len(fast.send) != 1 {
+		t.Errorf("reading client did not get the message sent after the eviction")
+	}
+}
+
+// BenchmarkHubDispatch рассылает события 1000 диспетчерам. Один клиент не
+// читает вовсе и должен быть отключен, второй стоит, но подписан только на
+// позиции — у него должна копиться одна последняя позиция, а не очередь.
+func BenchmarkHubDispatch(b *testing.B) {
+	hub := NewHub(nil, nil)
+
+	slow := newClient(nil, 1, structures.RoleDispatcher)
+	slow.subscriptions = []subscription{{Topic: TopicAll}}
+
+	stalled := newClient(nil, 2, structures.RoleDispatcher)
+	stalled.subscriptions = []subscription{{Topic: TopicApplication, ApplicationId: 2}}
+
+	hub.clients[slow] = true
+	hub.clients[stalled] = true
+
+	var readers sync.WaitGroup
+	for i := 3; i <= benchmarkClients; i++ {
+		cl := newClient(nil, i, structures.RoleDispatcher)
+		cl.subscriptions = []subscription{{Topic: TopicAll}}
+		hub.clients[cl] = true
+
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			cl.pump(
+				func([]byte) error { return nil },
+				func() error { return nil },
+			)
+		}()
+	}
+
+	status := outgoing{
+		meta:    &EventMeta{ApplicationId: 1, DroneId: 1},
+		message: []byte(`{"type":"status_update","application_id":1}`),
+	}
+	position := func(applicationId int) outgoing {
+		return outgoing{
+			meta:    &EventMeta{ApplicationId: applicationId, DroneId: applicationId, Coalesce: true},
+			message: []byte(`{"type":"position_update"}`),
+		}
+	}
+
+	var slowest time.Duration
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		started := time.Now()
+		hub.dispatch(position(1))
+		hub.dispatch(position(2))
+		hub.dispatch(status)
+		if elapsed := time.Since(started); elapsed > slowest {
+			slowest = elapsed
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(slowest.Microseconds()), "max-µs/op")
+
+	if b.N > sendQueueSize && hub.clients[slow] {
+		b.Errorf("slow client is still registered after %d undelivered messages", b.N)
+	}
+	if !hub.clients[stalled] {
+		b.Errorf("client with coalesced positions only was evicted")
+	}
+	if pending := len(stalled.positionOrder); pending != 1 {
+		b.Errorf("stalled client holds %d pending positions, want 1", pending)
+	}
+	if queued := len(stalled.send); queued != 0 {
+		b.Errorf("stalled client queued %d regular messages, want 0", queued)
+	}
+
+	for cl := range hub.clients {
+		cl.close()
+	}
+	readers.Wait()
+}
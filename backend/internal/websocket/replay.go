@@ -0,0 +1,435 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+var replaySpeeds = map[float64]bool{1: true, 4: true, 16: true}
+
+// События истории полета, у которых нет одноименного сообщения живого потока.
+const (
+	flightCancelledEvent    = "flight_cancelled"
+	flightReturnedHomeEvent = "flight_returned_home"
+)
+
+type replayCommand struct {
+	Action        string  `json:"action"`
+	ApplicationId int     `json:"application_id"`
+	Speed         float64 `json:"speed"`
+	OffsetSeconds float64 `json:"offset_seconds"`
+}
+
+type replayFrame struct {
+	at       time.Time
+	position bool
+	message  interface{}
+}
+
+// replaySession проигрывает записанный полет одному клиенту. Всё состояние
+// таймлайна принадлежит горутине run, команды приходят через control.
+type replaySession struct {
+	applicationId int
+	frames        []replayFrame
+	speed         float64
+	control       chan replayCommand
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func (h *Hub) startReplay(cl *client, command replayCommand) {
+	if cl.replay != nil {
+		cl.replay.close()
+		cl.replay = nil
+	}
+
+	speed := command.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	if !replaySpeeds[speed] {
+		cl.writeJSON(replayError(command.ApplicationId, "Replay speed must be 1, 4 or 16"))
+		return
+	}
+
+	if cl.role != structures.RoleDispatcher && h.applicationPilot(command.ApplicationId) != cl.userId {
+		cl.writeJSON(replayError(command.ApplicationId, "Application belongs to another pilot"))
+		return
+	}
+
+	frames, err := h.loadReplayFrames(command.ApplicationId)
+	if err != nil {
+		log.Printf("Error loading replay for application %d: %v", command.ApplicationId, err)
+		cl.writeJSON(replayError(command.ApplicationId, "Failed to load recorded flight"))
+		return
+	}
+
+	if len(frames) == 0 {
+		cl.writeJSON(replayError(command.ApplicationId, "No recorded flight for this application"))
+		return
+	}
+
+	session := &replaySession{
+		applicationId: command.ApplicationId,
+		frames:        frames,
+		speed:         speed,
+		control:       make(chan replayCommand),
+		done:          make(chan struct{}),
+	}
+	cl.replay = session
+
+	log.Printf("Starting replay of application %d: %d frames at %.0fx", command.ApplicationId, len(frames), speed)
+	go session.run(cl)
+}
+
+func (h *Hub) loadReplayFrames(applicationId int) ([]replayFrame, error) {
+	if h.flights == nil {
+		return nil, fmt.Errorf("flight source is not configured")
+	}
+
+	track, err := h.flights.SelectTrack(applicationId, time.Unix(0, 0).UTC(), time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := h.flights.SelectFlightEvents(applicationId)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]replayFrame, 0, len(track)+len(events))
+	for _, point := range track {
+		frames = append(frames, replayFrame{
+			at:       point.Timestamp,
+			position: true,
+			message:  replayPositionMessage(applicationId, point),
+		})
+	}
+	for _, event := range events {
+		frames = append(frames, replayFrame{
+			at:      event.CreatedAt,
+			message: replayEventMessage(event),
+		})
+	}
+
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].at.Before(frames[j].at)
+	})
+
+	return frames, nil
+}
+
+func (s *replaySession) send(command replayCommand) {
+	select {
+	case s.control <- command:
+	case <-s.done:
+	}
+}
+
+func (s *replaySession) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *replaySession) run(cl *client) {
+	defer s.close()
+
+	start := s.frames[0].at
+	end := s.frames[len(s.frames)-1].at
+
+	cursor := start
+	index := 0
+	paused := false
+	finished := false
+
+	status := func(statusType string) bool {
+		header := events.NewHeader(statusType)
+		header.Replay = true
+
+		err := cl.writeJSON(events.ReplayStatus{
+			Header:          header,
+			ApplicationId:   s.applicationId,
+			Speed:           s.speed,
+			PositionSeconds: cursor.Sub(start).Seconds(),
+			DurationSeconds: end.Sub(start).Seconds(),
+			StartTime:       start,
+			EndTime:         end,
+		})
+		return err == nil
+	}
+
+	handle := func(command replayCommand) bool {
+		switch command.Action {
+		case "replay_pause":
+			paused = true
+			return status(events.TypeReplayPaused)
+		case "replay_resume":
+			paused = false
+			return status(events.TypeReplayResumed)
+		case "replay_speed":
+			if !replaySpeeds[command.Speed] {
+				return cl.writeJSON(replayError(s.applicationId, "Replay speed must be 1, 4 or 16")) == nil
+			}
+			s.speed = command.Speed
+			return status(events.TypeReplaySpeedChanged)
+		case "replay_seek":
+			target := start.Add(time.Duration(command.OffsetSeconds * float64(time.Second)))
+			if target.Before(start) {
+				target = start
+			}
+			if target.After(end) {
+				target = end
+			}
+
+			cursor = target
+			index = sort.Search(len(s.frames), func(i int) bool {
+				return !s.frames[i].at.Before(target)
+			})
+			finished = false
+
+			if !status(events.TypeReplaySeeked) {
+				return false
+			}
+
+			// Сразу показываем последнюю известную позицию, чтобы карта не ждала следующей точки
+			for i := index - 1; i >= 0; i-- {
+				if s.frames[i].position {
+					return cl.writeJSON(s.frames[i].message) == nil
+				}
+			}
+		}
+		return true
+	}
+
+	if !status(events.TypeReplayStarted) {
+		return
+	}
+
+	for {
+		if index >= len(s.frames) && !finished {
+			finished = true
+			cursor = end
+			if !status(events.TypeReplayFinished) {
+				return
+			}
+		}
+
+		if paused || finished {
+			select {
+			case command := <-s.control:
+				if !handle(command) {
+					return
+				}
+			case <-s.done:
+				return
+			}
+			continue
+		}
+
+		frame := s.frames[index]
+		wait := time.Duration(float64(frame.at.Sub(cursor)) / s.speed)
+		waitStarted := time.Now()
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+			cursor = frame.at
+			index++
+			if err := cl.writeJSON(frame.message); err != nil {
+				return
+			}
+		case command := <-s.control:
+			timer.Stop()
+			cursor = cursor.Add(time.Duration(float64(time.Since(waitStarted)) * s.speed))
+			if cursor.After(frame.at) {
+				cursor = frame.at
+			}
+			if !handle(command) {
+				return
+			}
+		case <-s.done:
+			timer.Stop()
+			log.Printf("Replay of application %d stopped", s.applicationId)
+			return
+		}
+	}
+}
+
+func replayError(applicationId int, message string) events.ReplayError {
+	header := events.NewHeader(events.TypeReplayError)
+	header.Replay = true
+
+	return events.ReplayError{
+		Header:        header,
+		ApplicationId: applicationId,
+		Message:       message,
+	}
+}
+
+func replayPositionMessage(applicationId int, point structures.TrackPoint) events.PositionUpdate {
+	header := events.NewHeader(events.TypePositionUpdate)
+	header.Replay = true
+
+	return events.PositionUpdate{
+		Header:        header,
+		ApplicationId: applicationId,
+		DroneId:       point.DroneId,
+		Latitude:      point.Latitude,
+		Longitude:     point.Longitude,
+		Altitude:      point.Altitude,
+		Speed:         point.Speed,
+		Heading:       point.Heading,
+		RouteProgress: point.RouteProgress,
+		Timestamp:     point.Timestamp,
+	}
+}
+
+// replayEventMessage восстанавливает событие из истории полета в том же виде,
+// в котором оно приходило в живом потоке.
+func replayEventMessage(event structures.FlightEvent) interface{} {
+	position := &events.Position{
+		ApplicationId: event.ApplicationId,
+		DroneId:       event.DroneId,
+		Latitude:      event.Latitude,
+		Longitude:     event.Longitude,
+		Altitude:      event.Altitude,
+		Timestamp:     event.CreatedAt,
+	}
+
+	header := events.NewHeader(event.EventType)
+	header.Replay = true
+
+	switch {
+	case event.EventType == events.TypeFlightStarted:
+		return events.FlightStarted{
+			Header:          header,
+			ApplicationId:   event.ApplicationId,
+			DroneId:         event.DroneId,
+			CurrentPosition: position,
+			StartTime:       event.CreatedAt,
+			Message:         event.Message,
+		}
+	case event.EventType == events.TypeFlightPaused:
+		return events.FlightPaused{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			PausePosition: position,
+			PauseTime:     event.CreatedAt,
+			PauseReason:   event.Message,
+		}
+	case event.EventType == events.TypeFlightResumed:
+		return events.FlightResumed{
+			Header:         header,
+			ApplicationId:  event.ApplicationId,
+			DroneId:        event.DroneId,
+			ResumePosition: position,
+			ResumeTime:     event.CreatedAt,
+			ResumeReason:   event.Message,
+		}
+	case event.EventType == events.TypeFlightCompleted:
+		return events.FlightCompleted{
+			Header:         header,
+			ApplicationId:  event.ApplicationId,
+			DroneId:        event.DroneId,
+			FinalPosition:  position,
+			CompletionTime: event.CreatedAt,
+			Message:        event.Message,
+		}
+	case strings.HasPrefix(event.EventType, "route_deviation_"), strings.HasPrefix(event.EventType, "route_conformance_"):
+		header.Type = events.TypeRouteDeviation
+		return events.RouteDeviation{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			DeviationType: event.EventType[strings.LastIndex(event.EventType, "_")+1:],
+			AlertLevel:    event.AlertLevel,
+			DronePosition: position,
+			Timestamp:     event.CreatedAt,
+			Message:       event.Message,
+		}
+	case event.EventType == events.TypeTrafficConflict:
+		return events.TrafficConflict{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			AlertLevel:    event.AlertLevel,
+			DronePosition: position,
+			Timestamp:     event.CreatedAt,
+			Message:       event.Message,
+		}
+	case event.EventType == events.TypeWaypointAction:
+		var details struct {
+			Waypoint int           `json:"waypoint"`
+			Action   events.Action `json:"action"`
+			Phase    string        `json:"phase"`
+		}
+		// У событий, записанных до появления details, поля остаются пустыми
+		if len(event.Details) > 0 {
+			if err := json.Unmarshal(event.Details, &details); err != nil {
+				log.Printf("Invalid details of waypoint action event %d: %v", event.Id, err)
+			}
+		}
+
+		return events.WaypointAction{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			Waypoint:      details.Waypoint,
+			Action:        details.Action,
+			Phase:         details.Phase,
+			DronePosition: position,
+			Timestamp:     event.CreatedAt,
+			Message:       event.Message,
+		}
+	case event.EventType == events.TypeRestrictedZoneAlert:
+		return events.RestrictedZoneAlert{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			AlertLevel:    event.AlertLevel,
+			DronePosition: position,
+			Timestamp:     event.CreatedAt,
+			Message:       event.Message,
+		}
+	case event.EventType == flightCancelledEvent:
+		header.Type = events.TypeStatusUpdate
+		return events.StatusUpdate{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			Status:        string(structures.StatusCancelled),
+			Message:       event.Message,
+			Timestamp:     event.CreatedAt,
+		}
+	case event.EventType == flightReturnedHomeEvent:
+		header.Type = events.TypeFlightCompleted
+		return events.FlightCompleted{
+			Header:           header,
+			ApplicationId:    event.ApplicationId,
+			DroneId:          event.DroneId,
+			FinalPosition:    position,
+			CompletionTime:   event.CreatedAt,
+			CompletionStatus: "returned_home",
+			Message:          event.Message,
+		}
+	default:
+		return events.FlightEvent{
+			Header:        header,
+			ApplicationId: event.ApplicationId,
+			DroneId:       event.DroneId,
+			AlertLevel:    event.AlertLevel,
+			DronePosition: position,
+			Timestamp:     event.CreatedAt,
+			Message:       event.Message,
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+// HandleSSE отдает те же события, что и WebSocket, в формате Server-Sent Events.
+// Подписки задаются в query при подключении:
+//
+//	?application_id=1,2&drone_id=7&bbox=minLat,minLon,maxLat,maxLon&topic=all
+//
+// Без параметров клиент получает события своих заявок; own=true добавляет их
+// к явно перечисленным подпискам. Переподключение
+// продолжается с заголовка Last-Event-ID, который браузер выставляет сам.
+func (h *Hub) HandleSSE(c *fiber.Ctx) error {
+	userId, _ := c.Locals("userId").(int)
+	role, _ := c.Locals("role").(string)
+
+	cl := newClient(nil, userId, structures.Role(role))
+
+	subscriptions, err := parseSSESubscriptions(c)
+	if err != nil {
+		return apperror.Validation(err.Error())
+	}
+	for _, sub := range subscriptions {
+		if err := sub.validate(cl.role); err != nil {
+			return apperror.Validation(err.Error())
+		}
+	}
+	if len(subscriptions) > 0 {
+		cl.subscriptions = subscriptions
+	}
+
+	lastEventIdHeader := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	lastEventId, _ := strconv.ParseInt(lastEventIdHeader, 10, 64)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	remoteAddr := c.IP()
+	log.Printf("New SSE connection from %s", remoteAddr)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer func() {
+			log.Printf("Closing SSE connection from %s", remoteAddr)
+			h.unregister <- cl
+		}()
+
+		// Регистрация завершается до запуска pump, иначе отложенное снятие
+		// с регистрации могло бы обогнать ее и оставить в хабе мертвого
+		// клиента. Догрузка пропущенного ждет места в очереди, поэтому идет
+		// параллельно с ее разбором.
+		resume := h.registerClient(cl, lastEventId)
+		go resume()
+
+		cl.pump(
+			func(message []byte) error {
+				return writeSSEEvent(w, message)
+			},
+			func() error {
+				if _, err := w.WriteString(": keepalive\n\n"); err != nil {
+					return err
+				}
+				return w.Flush()
+			},
+		)
+	})
+
+	return nil
+}
+
+func writeSSEEvent(w *bufio.Writer, message []byte) error {
+	var header struct {
+		EventId int64  `json:"event_id"`
+		Type    string `json:"type"`
+	}
+	json.Unmarshal(message, &header)
+
+	if header.EventId > 0 {
+		fmt.Fprintf(w, "id: %d\n", header.EventId)
+	}
+	if header.Type != "" {
+		fmt.Fprintf(w, "event: %s\n", header.Type)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", message)
+
+	return w.Flush()
+}
+
+func parseSSESubscriptions(c *fiber.Ctx) ([]subscription, error) {
+	var subscriptions []subscription
+
+	if c.Query("topic") == TopicAll {
+		subscriptions = append(subscriptions, subscription{Topic: TopicAll})
+	}
+
+	applicationIds, err := parseIdList(c.Query("application_id"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid application_id: %w", err)
+	}
+	for _, id := range applicationIds {
+		subscriptions = append(subscriptions, subscription{Topic: TopicApplication, ApplicationId: id})
+	}
+
+	droneIds, err := parseIdList(c.Query("drone_id"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid drone_id: %w", err)
+	}
+	for _, id := range droneIds {
+		subscriptions = append(subscriptions, subscription{Topic: TopicDrone, DroneId: id})
+	}
+
+	if bbox := c.Query("bbox"); bbox != "" {
+		parts := strings.Split(bbox, ",")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("bbox must be minLat,minLon,maxLat,maxLon")
+		}
+
+		var values [4]float64
+		for i, part := range parts {
+			values[i], err = strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bbox: %w", err)
+			}
+		}
+
+		subscriptions = append(subscriptions, subscription{
+			Topic:        TopicBoundingBox,
+			MinLatitude:  values[0],
+			MinLongitude: values[1],
+			MaxLatitude:  values[2],
+			MaxLongitude: values[3],
+		})
+	}
+
+	if len(subscriptions) > 0 && c.Query("own") == "true" {
+		subscriptions = append(subscriptions, subscription{Topic: TopicOwn})
+	}
+
+	return subscriptions, nil
+}
+
+func parseIdList(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// EventSchema отдает JSON Schema сообщений, которые публикуют /ws и /events.
+func EventSchema(c *fiber.Ctx) error {
+	schema, err := events.JSONSchema()
+	if err != nil {
+		return apperror.Internal("Failed to build event schema", err)
+	}
+
+	c.Set("Content-Type", "application/schema+json")
+	return c.Send(schema)
+}
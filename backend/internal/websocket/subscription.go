@@ -0,0 +1,139 @@
+package websocket
+
+import (
+	"fmt"
+
+	"github.com/nxbodyevzncvre/decenthack/internal/events"
+	"github.com/nxbodyevzncvre/decenthack/internal/structures"
+)
+
+const (
+	TopicOwn         = "own"
+	TopicApplication = "application"
+	TopicDrone       = "drone"
+	TopicBoundingBox = "bbox"
+	TopicAll         = "all"
+)
+
+// EventMeta описывает, к какому полету относится событие, чтобы хаб
+// мог доставить его только подходящим подписчикам.
+type EventMeta struct {
+	ApplicationId int
+	DroneId       int
+	PilotId       int
+	Latitude      float64
+	Longitude     float64
+	HasPosition   bool
+	// Coalesce помечает события, которые медленному клиенту можно заменить
+	// более свежими: неотправленная позиция заявки вытесняется следующей.
+	Coalesce bool
+}
+
+type subscription events.Subscription
+
+type subscriptionCommand struct {
+	Action string `json:"action"`
+	subscription
+}
+
+func (s subscription) validate(role structures.Role) error {
+	switch s.Topic {
+	case TopicOwn:
+		return nil
+	case TopicApplication:
+		if s.ApplicationId <= 0 {
+			return fmt.Errorf("application_id is required for topic %q", s.Topic)
+		}
+	case TopicDrone:
+		if s.DroneId <= 0 {
+			return fmt.Errorf("drone_id is required for topic %q", s.Topic)
+		}
+	case TopicBoundingBox:
+		if s.MinLatitude >= s.MaxLatitude || s.MinLongitude >= s.MaxLongitude {
+			return fmt.Errorf("bounding box must have min_latitude < max_latitude and min_longitude < max_longitude")
+		}
+	case TopicAll:
+		if role != structures.RoleDispatcher {
+			return fmt.Errorf("topic %q is only available to dispatchers", s.Topic)
+		}
+	default:
+		return fmt.Errorf("unknown topic %q", s.Topic)
+	}
+	return nil
+}
+
+func (s subscription) matches(cl *client, meta EventMeta) bool {
+	switch s.Topic {
+	case TopicAll:
+		return true
+	case TopicOwn:
+		return meta.PilotId == cl.userId
+	case TopicApplication:
+		return meta.ApplicationId == s.ApplicationId
+	case TopicDrone:
+		return meta.DroneId == s.DroneId
+	case TopicBoundingBox:
+		return meta.HasPosition &&
+			meta.Latitude >= s.MinLatitude && meta.Latitude <= s.MaxLatitude &&
+			meta.Longitude >= s.MinLongitude && meta.Longitude <= s.MaxLongitude
+	}
+	return false
+}
+
+// receives решает, доставлять ли событие клиенту. Пилоты никогда не получают
+// чужие полеты, какие бы темы они ни выбрали; диспетчерам доступно всё.
+func (c *client) receives(meta *EventMeta) bool {
+	if meta == nil {
+		return true
+	}
+
+	if c.role != structures.RoleDispatcher && meta.PilotId != c.userId {
+		return false
+	}
+
+	c.subscriptionsMutex.RLock()
+	defer c.subscriptionsMutex.RUnlock()
+
+	for _, sub := range c.subscriptions {
+		if sub.matches(c, *meta) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *client) subscribe(sub subscription) {
+	c.subscriptionsMutex.Lock()
+	defer c.subscriptionsMutex.Unlock()
+
+	for _, existing := range c.subscriptions {
+		if existing == sub {
+			return
+		}
+	}
+	c.subscriptions = append(c.subscriptions, sub)
+}
+
+func (c *client) unsubscribe(sub subscription) {
+	c.subscriptionsMutex.Lock()
+	defer c.subscriptionsMutex.Unlock()
+
+	kept := c.subscriptions[:0]
+	for _, existing := range c.subscriptions {
+		if existing != sub {
+			kept = append(kept, existing)
+		}
+	}
+	c.subscriptions = kept
+}
+
+func (c *client) currentSubscriptions() []events.Subscription {
+	c.subscriptionsMutex.RLock()
+	defer c.subscriptionsMutex.RUnlock()
+
+	result := make([]events.Subscription, len(c.subscriptions))
+	for i, sub := range c.subscriptions {
+		result[i] = events.Subscription(sub)
+	}
+	return result
+}
@@ -6,9 +6,10 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateAccessToken(id int, secretKey string) (string, error) {
+func GenerateAccessToken(id int, role string, secretKey string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"userId": id,
+		"role":   role,
 		"exp":    time.Now().Add(time.Minute * 60).Unix(),
 		"type":   "access",
 	})
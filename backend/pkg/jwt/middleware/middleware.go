@@ -6,18 +6,19 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/nxbodyevzncvre/decenthack/internal/apperror"
 )
 
 func JWTMiddleware(secretKey string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing auth header"})
+			return apperror.Unauthorized("Missing auth header")
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid auth format"})
+			return apperror.Unauthorized("Invalid auth format")
 		}
 
 		tokenStr := parts[1]
@@ -27,20 +28,26 @@ func JWTMiddleware(secretKey string) fiber.Handler {
 		})
 		if err != nil {
 			log.Error("error with")
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid token"})
+			return apperror.Unauthorized("Invalid token")
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid token claims"})
+			return apperror.Unauthorized("Invalid token claims")
 		}
 
 		userId, ok := claims["userId"].(float64)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid userId in token"})
+			return apperror.Unauthorized("Invalid userId in token")
+		}
+
+		role, _ := claims["role"].(string)
+		if role == "" {
+			role = "pilot"
 		}
 
 		c.Locals("userId", int(userId))
+		c.Locals("role", role)
 
 		return c.Next()
 	}
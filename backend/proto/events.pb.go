@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: proto/events.proto
+
+package flight
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventFrame — бинарное представление событий WebSocket для клиентов,
+// подключившихся с format=protobuf. Позиции, составляющие почти весь трафик,
+// передаются типизированно, остальные события — JSON той же схемы.
+type EventFrame struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	EventId int64                  `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Replay  bool                   `protobuf:"varint,4,opt,name=replay,proto3" json:"replay,omitempty"`
+	// Types that are valid to be assigned to Body:
+	//
+	//	*EventFrame_PositionUpdate
+	//	*EventFrame_Json
+	Body          isEventFrame_Body `protobuf_oneof:"body"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventFrame) Reset() {
+	*x = EventFrame{}
+	mi := &file_proto_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventFrame) ProtoMessage() {}
+
+func (x *EventFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventFrame.ProtoReflect.Descriptor instead.
+func (*EventFrame) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventFrame) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EventFrame) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *EventFrame) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventFrame) GetReplay() bool {
+	if x != nil {
+		return x.Replay
+	}
+	return false
+}
+
+func (x *EventFrame) GetBody() isEventFrame_Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *EventFrame) GetPositionUpdate() *PositionUpdateEvent {
+	if x != nil {
+		if x, ok := x.Body.(*EventFrame_PositionUpdate); ok {
+			return x.PositionUpdate
+		}
+	}
+	return nil
+}
+
+func (x *EventFrame) GetJson() []byte {
+	if x != nil {
+		if x, ok := x.Body.(*EventFrame_Json); ok {
+			return x.Json
+		}
+	}
+	return nil
+}
+
+type isEventFrame_Body interface {
+	isEventFrame_Body()
+}
+
+type EventFrame_PositionUpdate struct {
+	PositionUpdate *PositionUpdateEvent `protobuf:"bytes,10,opt,name=position_update,json=positionUpdate,proto3,oneof"`
+}
+
+type EventFrame_Json struct {
+	Json []byte `protobuf:"bytes,15,opt,name=json,proto3,oneof"`
+}
+
+func (*EventFrame_PositionUpdate) isEventFrame_Body() {}
+
+func (*EventFrame_Json) isEventFrame_Body() {}
+
+type PositionUpdateEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId     int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId           int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Latitude          float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude         float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude          float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Speed             float64                `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	Heading           float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
+	RouteProgress     float64                `protobuf:"fixed64,8,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
+	Timestamp         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EstimatedEndTime  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=estimated_end_time,json=estimatedEndTime,proto3" json:"estimated_end_time,omitempty"`
+	DistanceRemaining float64                `protobuf:"fixed64,11,opt,name=distance_remaining,json=distanceRemaining,proto3" json:"distance_remaining,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PositionUpdateEvent) Reset() {
+	*x = PositionUpdateEvent{}
+	mi := &file_proto_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionUpdateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionUpdateEvent) ProtoMessage() {}
+
+func (x *PositionUpdateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionUpdateEvent.ProtoReflect.Descriptor instead.
+func (*PositionUpdateEvent) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PositionUpdateEvent) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetRouteProgress() float64 {
+	if x != nil {
+		return x.RouteProgress
+	}
+	return 0
+}
+
+func (x *PositionUpdateEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PositionUpdateEvent) GetEstimatedEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedEndTime
+	}
+	return nil
+}
+
+func (x *PositionUpdateEvent) GetDistanceRemaining() float64 {
+	if x != nil {
+		return x.DistanceRemaining
+	}
+	return 0
+}
+
+var File_proto_events_proto protoreflect.FileDescriptor
+
+const file_proto_events_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/events.proto\x12\x06flight\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x01\n" +
+	"\n" +
+	"EventFrame\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06replay\x18\x04 \x01(\bR\x06replay\x12F\n" +
+	"\x0fposition_update\x18\n" +
+	" \x01(\v2\x1b.flight.PositionUpdateEventH\x00R\x0epositionUpdate\x12\x14\n" +
+	"\x04json\x18\x0f \x01(\fH\x00R\x04jsonB\x06\n" +
+	"\x04body\"\xb7\x03\n" +
+	"\x13PositionUpdateEvent\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
+	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x05 \x01(\x01R\baltitude\x12\x14\n" +
+	"\x05speed\x18\x06 \x01(\x01R\x05speed\x12\x18\n" +
+	"\aheading\x18\a \x01(\x01R\aheading\x12%\n" +
+	"\x0eroute_progress\x18\b \x01(\x01R\rrouteProgress\x128\n" +
+	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12H\n" +
+	"\x12estimated_end_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedEndTime\x12-\n" +
+	"\x12distance_remaining\x18\v \x01(\x01R\x11distanceRemainingB\x0eZ\fproto/flightb\x06proto3"
+
+var (
+	file_proto_events_proto_rawDescOnce sync.Once
+	file_proto_events_proto_rawDescData []byte
+)
+
+func file_proto_events_proto_rawDescGZIP() []byte {
+	file_proto_events_proto_rawDescOnce.Do(func() {
+		file_proto_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)))
+	})
+	return file_proto_events_proto_rawDescData
+}
+
+var file_proto_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_events_proto_goTypes = []any{
+	(*EventFrame)(nil),            // 0: flight.EventFrame
+	(*PositionUpdateEvent)(nil),   // 1: flight.PositionUpdateEvent
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_proto_events_proto_depIdxs = []int32{
+	1, // 0: flight.EventFrame.position_update:type_name -> flight.PositionUpdateEvent
+	2, // 1: flight.PositionUpdateEvent.timestamp:type_name -> google.protobuf.Timestamp
+	2, // 2: flight.PositionUpdateEvent.estimated_end_time:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_events_proto_init() }
+func file_proto_events_proto_init() {
+	if File_proto_events_proto != nil {
+		return
+	}
+	file_proto_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*EventFrame_PositionUpdate)(nil),
+		(*EventFrame_Json)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_events_proto_goTypes,
+		DependencyIndexes: file_proto_events_proto_depIdxs,
+		MessageInfos:      file_proto_events_proto_msgTypes,
+	}.Build()
+	File_proto_events_proto = out.File
+	file_proto_events_proto_goTypes = nil
+	file_proto_events_proto_depIdxs = nil
+}
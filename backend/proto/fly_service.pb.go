@@ -29,6 +29,7 @@ type StatusUpdateRequest struct {
 	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	RejectionReason string                 `protobuf:"bytes,4,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"`
 	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EventId         string                 `protobuf:"bytes,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -98,6 +99,13 @@ func (x *StatusUpdateRequest) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *StatusUpdateRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type StatusUpdateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -159,6 +167,7 @@ type FlightStartedRequest struct {
 	CurrentPosition  *DronePosition         `protobuf:"bytes,5,opt,name=current_position,json=currentPosition,proto3" json:"current_position,omitempty"`
 	StartTime        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 	EstimatedEndTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=estimated_end_time,json=estimatedEndTime,proto3" json:"estimated_end_time,omitempty"`
+	EventId          string                 `protobuf:"bytes,8,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -242,6 +251,13 @@ func (x *FlightStartedRequest) GetEstimatedEndTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *FlightStartedRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type FlightStartedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -295,18 +311,21 @@ func (x *FlightStartedResponse) GetErrorMessage() string {
 }
 
 type DronePositionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
-	DroneId       int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Altitude      float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
-	Speed         float64                `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
-	Heading       float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
-	RouteProgress float64                `protobuf:"fixed64,8,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId     int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId           int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Latitude          float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude         float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude          float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Speed             float64                `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	Heading           float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
+	RouteProgress     float64                `protobuf:"fixed64,8,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
+	Timestamp         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EstimatedEndTime  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=estimated_end_time,json=estimatedEndTime,proto3" json:"estimated_end_time,omitempty"`
+	DistanceRemaining float64                `protobuf:"fixed64,11,opt,name=distance_remaining,json=distanceRemaining,proto3" json:"distance_remaining,omitempty"`
+	EventId           string                 `protobuf:"bytes,12,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *DronePositionRequest) Reset() {
@@ -402,6 +421,27 @@ func (x *DronePositionRequest) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DronePositionRequest) GetEstimatedEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedEndTime
+	}
+	return nil
+}
+
+func (x *DronePositionRequest) GetDistanceRemaining() float64 {
+	if x != nil {
+		return x.DistanceRemaining
+	}
+	return 0
+}
+
+func (x *DronePositionRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type DronePositionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -454,6 +494,137 @@ func (x *DronePositionResponse) GetErrorMessage() string {
 	return ""
 }
 
+// TelemetryBatch объединяет позиции всех активных полетов за один интервал.
+type TelemetryBatch struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Sequence      int64                   `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Positions     []*DronePositionRequest `protobuf:"bytes,2,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryBatch) Reset() {
+	*x = TelemetryBatch{}
+	mi := &file_proto_fly_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryBatch) ProtoMessage() {}
+
+func (x *TelemetryBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryBatch.ProtoReflect.Descriptor instead.
+func (*TelemetryBatch) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TelemetryBatch) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TelemetryBatch) GetPositions() []*DronePositionRequest {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// TelemetryAck подтверждает батч; window — сколько батчей клиент может
+// держать неподтвержденными.
+type TelemetryAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Accepted      int32                  `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Window        int32                  `protobuf:"varint,5,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryAck) Reset() {
+	*x = TelemetryAck{}
+	mi := &file_proto_fly_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryAck) ProtoMessage() {}
+
+func (x *TelemetryAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryAck.ProtoReflect.Descriptor instead.
+func (*TelemetryAck) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TelemetryAck) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TelemetryAck) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TelemetryAck) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TelemetryAck) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *TelemetryAck) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
 type FlightCompletedRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ApplicationId    int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
@@ -461,13 +632,14 @@ type FlightCompletedRequest struct {
 	FinalPosition    *DronePosition         `protobuf:"bytes,3,opt,name=final_position,json=finalPosition,proto3" json:"final_position,omitempty"`
 	CompletionTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=completion_time,json=completionTime,proto3" json:"completion_time,omitempty"`
 	CompletionStatus string                 `protobuf:"bytes,5,opt,name=completion_status,json=completionStatus,proto3" json:"completion_status,omitempty"`
+	EventId          string                 `protobuf:"bytes,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *FlightCompletedRequest) Reset() {
 	*x = FlightCompletedRequest{}
-	mi := &file_proto_fly_service_proto_msgTypes[6]
+	mi := &file_proto_fly_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -479,7 +651,7 @@ func (x *FlightCompletedRequest) String() string {
 func (*FlightCompletedRequest) ProtoMessage() {}
 
 func (x *FlightCompletedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[6]
+	mi := &file_proto_fly_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +664,7 @@ func (x *FlightCompletedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightCompletedRequest.ProtoReflect.Descriptor instead.
 func (*FlightCompletedRequest) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{6}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FlightCompletedRequest) GetApplicationId() int32 {
@@ -530,6 +702,13 @@ func (x *FlightCompletedRequest) GetCompletionStatus() string {
 	return ""
 }
 
+func (x *FlightCompletedRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type FlightCompletedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -540,7 +719,7 @@ type FlightCompletedResponse struct {
 
 func (x *FlightCompletedResponse) Reset() {
 	*x = FlightCompletedResponse{}
-	mi := &file_proto_fly_service_proto_msgTypes[7]
+	mi := &file_proto_fly_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +731,7 @@ func (x *FlightCompletedResponse) String() string {
 func (*FlightCompletedResponse) ProtoMessage() {}
 
 func (x *FlightCompletedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[7]
+	mi := &file_proto_fly_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +744,7 @@ func (x *FlightCompletedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightCompletedResponse.ProtoReflect.Descriptor instead.
 func (*FlightCompletedResponse) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{7}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FlightCompletedResponse) GetSuccess() bool {
@@ -594,13 +773,14 @@ type RestrictedZoneAlertRequest struct {
 	Distance      float64                `protobuf:"fixed64,8,opt,name=distance,proto3" json:"distance,omitempty"`
 	DronePosition *DronePosition         `protobuf:"bytes,9,opt,name=drone_position,json=dronePosition,proto3" json:"drone_position,omitempty"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EventId       string                 `protobuf:"bytes,11,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RestrictedZoneAlertRequest) Reset() {
 	*x = RestrictedZoneAlertRequest{}
-	mi := &file_proto_fly_service_proto_msgTypes[8]
+	mi := &file_proto_fly_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -612,7 +792,7 @@ func (x *RestrictedZoneAlertRequest) String() string {
 func (*RestrictedZoneAlertRequest) ProtoMessage() {}
 
 func (x *RestrictedZoneAlertRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[8]
+	mi := &file_proto_fly_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +805,7 @@ func (x *RestrictedZoneAlertRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestrictedZoneAlertRequest.ProtoReflect.Descriptor instead.
 func (*RestrictedZoneAlertRequest) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{8}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RestrictedZoneAlertRequest) GetApplicationId() int32 {
@@ -698,6 +878,13 @@ func (x *RestrictedZoneAlertRequest) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RestrictedZoneAlertRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type RestrictedZoneAlertResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -708,7 +895,7 @@ type RestrictedZoneAlertResponse struct {
 
 func (x *RestrictedZoneAlertResponse) Reset() {
 	*x = RestrictedZoneAlertResponse{}
-	mi := &file_proto_fly_service_proto_msgTypes[9]
+	mi := &file_proto_fly_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +907,7 @@ func (x *RestrictedZoneAlertResponse) String() string {
 func (*RestrictedZoneAlertResponse) ProtoMessage() {}
 
 func (x *RestrictedZoneAlertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[9]
+	mi := &file_proto_fly_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +920,7 @@ func (x *RestrictedZoneAlertResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestrictedZoneAlertResponse.ProtoReflect.Descriptor instead.
 func (*RestrictedZoneAlertResponse) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{9}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RestrictedZoneAlertResponse) GetSuccess() bool {
@@ -757,13 +944,14 @@ type FlightPausedRequest struct {
 	PausePosition *DronePosition         `protobuf:"bytes,3,opt,name=pause_position,json=pausePosition,proto3" json:"pause_position,omitempty"`
 	PauseTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=pause_time,json=pauseTime,proto3" json:"pause_time,omitempty"`
 	PauseReason   string                 `protobuf:"bytes,5,opt,name=pause_reason,json=pauseReason,proto3" json:"pause_reason,omitempty"`
+	EventId       string                 `protobuf:"bytes,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FlightPausedRequest) Reset() {
 	*x = FlightPausedRequest{}
-	mi := &file_proto_fly_service_proto_msgTypes[10]
+	mi := &file_proto_fly_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +963,7 @@ func (x *FlightPausedRequest) String() string {
 func (*FlightPausedRequest) ProtoMessage() {}
 
 func (x *FlightPausedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[10]
+	mi := &file_proto_fly_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +976,7 @@ func (x *FlightPausedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightPausedRequest.ProtoReflect.Descriptor instead.
 func (*FlightPausedRequest) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{10}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *FlightPausedRequest) GetApplicationId() int32 {
@@ -826,6 +1014,13 @@ func (x *FlightPausedRequest) GetPauseReason() string {
 	return ""
 }
 
+func (x *FlightPausedRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type FlightPausedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -836,7 +1031,7 @@ type FlightPausedResponse struct {
 
 func (x *FlightPausedResponse) Reset() {
 	*x = FlightPausedResponse{}
-	mi := &file_proto_fly_service_proto_msgTypes[11]
+	mi := &file_proto_fly_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +1043,7 @@ func (x *FlightPausedResponse) String() string {
 func (*FlightPausedResponse) ProtoMessage() {}
 
 func (x *FlightPausedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[11]
+	mi := &file_proto_fly_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,7 +1056,7 @@ func (x *FlightPausedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightPausedResponse.ProtoReflect.Descriptor instead.
 func (*FlightPausedResponse) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{11}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FlightPausedResponse) GetSuccess() bool {
@@ -885,13 +1080,14 @@ type FlightResumedRequest struct {
 	ResumePosition *DronePosition         `protobuf:"bytes,3,opt,name=resume_position,json=resumePosition,proto3" json:"resume_position,omitempty"`
 	ResumeTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=resume_time,json=resumeTime,proto3" json:"resume_time,omitempty"`
 	ResumeReason   string                 `protobuf:"bytes,5,opt,name=resume_reason,json=resumeReason,proto3" json:"resume_reason,omitempty"`
+	EventId        string                 `protobuf:"bytes,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FlightResumedRequest) Reset() {
 	*x = FlightResumedRequest{}
-	mi := &file_proto_fly_service_proto_msgTypes[12]
+	mi := &file_proto_fly_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -903,7 +1099,7 @@ func (x *FlightResumedRequest) String() string {
 func (*FlightResumedRequest) ProtoMessage() {}
 
 func (x *FlightResumedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[12]
+	mi := &file_proto_fly_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -916,7 +1112,7 @@ func (x *FlightResumedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightResumedRequest.ProtoReflect.Descriptor instead.
 func (*FlightResumedRequest) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{12}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *FlightResumedRequest) GetApplicationId() int32 {
@@ -954,6 +1150,13 @@ func (x *FlightResumedRequest) GetResumeReason() string {
 	return ""
 }
 
+func (x *FlightResumedRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type FlightResumedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -964,7 +1167,7 @@ type FlightResumedResponse struct {
 
 func (x *FlightResumedResponse) Reset() {
 	*x = FlightResumedResponse{}
-	mi := &file_proto_fly_service_proto_msgTypes[13]
+	mi := &file_proto_fly_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -976,7 +1179,7 @@ func (x *FlightResumedResponse) String() string {
 func (*FlightResumedResponse) ProtoMessage() {}
 
 func (x *FlightResumedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[13]
+	mi := &file_proto_fly_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,7 +1192,7 @@ func (x *FlightResumedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlightResumedResponse.ProtoReflect.Descriptor instead.
 func (*FlightResumedResponse) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{13}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *FlightResumedResponse) GetSuccess() bool {
@@ -1006,33 +1209,39 @@ func (x *FlightResumedResponse) GetErrorMessage() string {
 	return ""
 }
 
-type RoutePoint struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Altitude      float64                `protobuf:"fixed64,4,opt,name=altitude,proto3" json:"altitude,omitempty"`
-	PointOrder    int32                  `protobuf:"varint,5,opt,name=point_order,json=pointOrder,proto3" json:"point_order,omitempty"`
-	ApplicationId int32                  `protobuf:"varint,6,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RoutePoint) Reset() {
-	*x = RoutePoint{}
-	mi := &file_proto_fly_service_proto_msgTypes[14]
+type TrafficConflictRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId         int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId               int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	IntruderApplicationId int32                  `protobuf:"varint,3,opt,name=intruder_application_id,json=intruderApplicationId,proto3" json:"intruder_application_id,omitempty"`
+	IntruderDroneId       int32                  `protobuf:"varint,4,opt,name=intruder_drone_id,json=intruderDroneId,proto3" json:"intruder_drone_id,omitempty"`
+	AlertLevel            string                 `protobuf:"bytes,5,opt,name=alert_level,json=alertLevel,proto3" json:"alert_level,omitempty"`
+	HorizontalSeparation  float64                `protobuf:"fixed64,6,opt,name=horizontal_separation,json=horizontalSeparation,proto3" json:"horizontal_separation,omitempty"`
+	VerticalSeparation    float64                `protobuf:"fixed64,7,opt,name=vertical_separation,json=verticalSeparation,proto3" json:"vertical_separation,omitempty"`
+	Resolution            string                 `protobuf:"bytes,8,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	DronePosition         *DronePosition         `protobuf:"bytes,9,opt,name=drone_position,json=dronePosition,proto3" json:"drone_position,omitempty"`
+	IntruderPosition      *DronePosition         `protobuf:"bytes,10,opt,name=intruder_position,json=intruderPosition,proto3" json:"intruder_position,omitempty"`
+	Timestamp             *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EventId               string                 `protobuf:"bytes,12,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TrafficConflictRequest) Reset() {
+	*x = TrafficConflictRequest{}
+	mi := &file_proto_fly_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoutePoint) String() string {
+func (x *TrafficConflictRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoutePoint) ProtoMessage() {}
+func (*TrafficConflictRequest) ProtoMessage() {}
 
-func (x *RoutePoint) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[14]
+func (x *TrafficConflictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,71 +1252,664 @@ func (x *RoutePoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoutePoint.ProtoReflect.Descriptor instead.
-func (*RoutePoint) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use TrafficConflictRequest.ProtoReflect.Descriptor instead.
+func (*TrafficConflictRequest) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *RoutePoint) GetId() int32 {
+func (x *TrafficConflictRequest) GetApplicationId() int32 {
 	if x != nil {
-		return x.Id
+		return x.ApplicationId
 	}
 	return 0
 }
 
-func (x *RoutePoint) GetLatitude() float64 {
+func (x *TrafficConflictRequest) GetDroneId() int32 {
 	if x != nil {
-		return x.Latitude
+		return x.DroneId
 	}
 	return 0
 }
 
-func (x *RoutePoint) GetLongitude() float64 {
+func (x *TrafficConflictRequest) GetIntruderApplicationId() int32 {
 	if x != nil {
-		return x.Longitude
+		return x.IntruderApplicationId
 	}
 	return 0
 }
 
-func (x *RoutePoint) GetAltitude() float64 {
+func (x *TrafficConflictRequest) GetIntruderDroneId() int32 {
 	if x != nil {
-		return x.Altitude
+		return x.IntruderDroneId
 	}
 	return 0
 }
 
-func (x *RoutePoint) GetPointOrder() int32 {
+func (x *TrafficConflictRequest) GetAlertLevel() string {
 	if x != nil {
-		return x.PointOrder
+		return x.AlertLevel
+	}
+	return ""
+}
+
+func (x *TrafficConflictRequest) GetHorizontalSeparation() float64 {
+	if x != nil {
+		return x.HorizontalSeparation
 	}
 	return 0
 }
 
-func (x *RoutePoint) GetApplicationId() int32 {
+func (x *TrafficConflictRequest) GetVerticalSeparation() float64 {
 	if x != nil {
-		return x.ApplicationId
+		return x.VerticalSeparation
 	}
 	return 0
 }
 
-type DronePosition struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
-	DroneId       int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Altitude      float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
-	Speed         float64                `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
-	Heading       float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
-	RouteProgress float64                `protobuf:"fixed64,8,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TrafficConflictRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *TrafficConflictRequest) GetDronePosition() *DronePosition {
+	if x != nil {
+		return x.DronePosition
+	}
+	return nil
+}
+
+func (x *TrafficConflictRequest) GetIntruderPosition() *DronePosition {
+	if x != nil {
+		return x.IntruderPosition
+	}
+	return nil
+}
+
+func (x *TrafficConflictRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TrafficConflictRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type TrafficConflictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficConflictResponse) Reset() {
+	*x = TrafficConflictResponse{}
+	mi := &file_proto_fly_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficConflictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficConflictResponse) ProtoMessage() {}
+
+func (x *TrafficConflictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficConflictResponse.ProtoReflect.Descriptor instead.
+func (*TrafficConflictResponse) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TrafficConflictResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TrafficConflictResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RouteDeviationRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId        int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId              int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	DeviationType        string                 `protobuf:"bytes,3,opt,name=deviation_type,json=deviationType,proto3" json:"deviation_type,omitempty"`
+	AlertLevel           string                 `protobuf:"bytes,4,opt,name=alert_level,json=alertLevel,proto3" json:"alert_level,omitempty"`
+	LateralDeviation     float64                `protobuf:"fixed64,5,opt,name=lateral_deviation,json=lateralDeviation,proto3" json:"lateral_deviation,omitempty"`
+	VerticalDeviation    float64                `protobuf:"fixed64,6,opt,name=vertical_deviation,json=verticalDeviation,proto3" json:"vertical_deviation,omitempty"`
+	TimeDeviationSeconds float64                `protobuf:"fixed64,7,opt,name=time_deviation_seconds,json=timeDeviationSeconds,proto3" json:"time_deviation_seconds,omitempty"`
+	Leg                  int32                  `protobuf:"varint,8,opt,name=leg,proto3" json:"leg,omitempty"`
+	DronePosition        *DronePosition         `protobuf:"bytes,9,opt,name=drone_position,json=dronePosition,proto3" json:"drone_position,omitempty"`
+	Timestamp            *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EventId              string                 `protobuf:"bytes,11,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RouteDeviationRequest) Reset() {
+	*x = RouteDeviationRequest{}
+	mi := &file_proto_fly_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteDeviationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteDeviationRequest) ProtoMessage() {}
+
+func (x *RouteDeviationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteDeviationRequest.ProtoReflect.Descriptor instead.
+func (*RouteDeviationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RouteDeviationRequest) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetDeviationType() string {
+	if x != nil {
+		return x.DeviationType
+	}
+	return ""
+}
+
+func (x *RouteDeviationRequest) GetAlertLevel() string {
+	if x != nil {
+		return x.AlertLevel
+	}
+	return ""
+}
+
+func (x *RouteDeviationRequest) GetLateralDeviation() float64 {
+	if x != nil {
+		return x.LateralDeviation
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetVerticalDeviation() float64 {
+	if x != nil {
+		return x.VerticalDeviation
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetTimeDeviationSeconds() float64 {
+	if x != nil {
+		return x.TimeDeviationSeconds
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetLeg() int32 {
+	if x != nil {
+		return x.Leg
+	}
+	return 0
+}
+
+func (x *RouteDeviationRequest) GetDronePosition() *DronePosition {
+	if x != nil {
+		return x.DronePosition
+	}
+	return nil
+}
+
+func (x *RouteDeviationRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *RouteDeviationRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type RouteDeviationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteDeviationResponse) Reset() {
+	*x = RouteDeviationResponse{}
+	mi := &file_proto_fly_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteDeviationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteDeviationResponse) ProtoMessage() {}
+
+func (x *RouteDeviationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteDeviationResponse.ProtoReflect.Descriptor instead.
+func (*RouteDeviationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RouteDeviationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RouteDeviationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type WaypointActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId       int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Waypoint      int32                  `protobuf:"varint,3,opt,name=waypoint,proto3" json:"waypoint,omitempty"`
+	Action        *WaypointAction        `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Phase         string                 `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`
+	DronePosition *DronePosition         `protobuf:"bytes,6,opt,name=drone_position,json=dronePosition,proto3" json:"drone_position,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EventId       string                 `protobuf:"bytes,8,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaypointActionRequest) Reset() {
+	*x = WaypointActionRequest{}
+	mi := &file_proto_fly_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaypointActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaypointActionRequest) ProtoMessage() {}
+
+func (x *WaypointActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaypointActionRequest.ProtoReflect.Descriptor instead.
+func (*WaypointActionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WaypointActionRequest) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *WaypointActionRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *WaypointActionRequest) GetWaypoint() int32 {
+	if x != nil {
+		return x.Waypoint
+	}
+	return 0
+}
+
+func (x *WaypointActionRequest) GetAction() *WaypointAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *WaypointActionRequest) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *WaypointActionRequest) GetDronePosition() *DronePosition {
+	if x != nil {
+		return x.DronePosition
+	}
+	return nil
+}
+
+func (x *WaypointActionRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WaypointActionRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type WaypointActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaypointActionResponse) Reset() {
+	*x = WaypointActionResponse{}
+	mi := &file_proto_fly_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaypointActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaypointActionResponse) ProtoMessage() {}
+
+func (x *WaypointActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaypointActionResponse.ProtoReflect.Descriptor instead.
+func (*WaypointActionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WaypointActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WaypointActionResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RoutePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,4,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	PointOrder    int32                  `protobuf:"varint,5,opt,name=point_order,json=pointOrder,proto3" json:"point_order,omitempty"`
+	ApplicationId int32                  `protobuf:"varint,6,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	Actions       []*WaypointAction      `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutePoint) Reset() {
+	*x = RoutePoint{}
+	mi := &file_proto_fly_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutePoint) ProtoMessage() {}
+
+func (x *RoutePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutePoint.ProtoReflect.Descriptor instead.
+func (*RoutePoint) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RoutePoint) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetPointOrder() int32 {
+	if x != nil {
+		return x.PointOrder
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *RoutePoint) GetActions() []*WaypointAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+// WaypointAction — действие в точке маршрута. Используются только поля,
+// относящиеся к типу: seconds для hover, radius и turns для orbit, speed
+// для change_speed.
+type WaypointAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Seconds       float64                `protobuf:"fixed64,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Radius        float64                `protobuf:"fixed64,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	Turns         float64                `protobuf:"fixed64,4,opt,name=turns,proto3" json:"turns,omitempty"`
+	Speed         float64                `protobuf:"fixed64,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaypointAction) Reset() {
+	*x = WaypointAction{}
+	mi := &file_proto_fly_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaypointAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaypointAction) ProtoMessage() {}
+
+func (x *WaypointAction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fly_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaypointAction.ProtoReflect.Descriptor instead.
+func (*WaypointAction) Descriptor() ([]byte, []int) {
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WaypointAction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WaypointAction) GetSeconds() float64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *WaypointAction) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *WaypointAction) GetTurns() float64 {
+	if x != nil {
+		return x.Turns
+	}
+	return 0
+}
+
+func (x *WaypointAction) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type DronePosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	DroneId       int32                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Speed         float64                `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	Heading       float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
+	RouteProgress float64                `protobuf:"fixed64,8,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DronePosition) Reset() {
 	*x = DronePosition{}
-	mi := &file_proto_fly_service_proto_msgTypes[15]
+	mi := &file_proto_fly_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1119,7 +1921,7 @@ func (x *DronePosition) String() string {
 func (*DronePosition) ProtoMessage() {}
 
 func (x *DronePosition) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fly_service_proto_msgTypes[15]
+	mi := &file_proto_fly_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1132,7 +1934,7 @@ func (x *DronePosition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DronePosition.ProtoReflect.Descriptor instead.
 func (*DronePosition) Descriptor() ([]byte, []int) {
-	return file_proto_fly_service_proto_rawDescGZIP(), []int{15}
+	return file_proto_fly_service_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DronePosition) GetApplicationId() int32 {
@@ -1202,16 +2004,17 @@ var File_proto_fly_service_proto protoreflect.FileDescriptor
 
 const file_proto_fly_service_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/fly_service.proto\x12\x06flight\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x01\n" +
+	"\x17proto/fly_service.proto\x12\x06flight\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x01\n" +
 	"\x13StatusUpdateRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12)\n" +
 	"\x10rejection_reason\x18\x04 \x01(\tR\x0frejectionReason\x128\n" +
-	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"U\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\tR\aeventId\"U\n" +
 	"\x14StatusUpdateResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xe4\x02\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xff\x02\n" +
 	"\x14FlightStartedRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x19\n" +
@@ -1220,10 +2023,11 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\x10current_position\x18\x05 \x01(\v2\x15.flight.DronePositionR\x0fcurrentPosition\x129\n" +
 	"\n" +
 	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12H\n" +
-	"\x12estimated_end_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedEndTime\"V\n" +
+	"\x12estimated_end_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedEndTime\x12\x19\n" +
+	"\bevent_id\x18\b \x01(\tR\aeventId\"V\n" +
 	"\x15FlightStartedResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xbf\x02\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xd3\x03\n" +
 	"\x14DronePositionRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x1a\n" +
@@ -1233,19 +2037,33 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\x05speed\x18\x06 \x01(\x01R\x05speed\x12\x18\n" +
 	"\aheading\x18\a \x01(\x01R\aheading\x12%\n" +
 	"\x0eroute_progress\x18\b \x01(\x01R\rrouteProgress\x128\n" +
-	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"V\n" +
+	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12H\n" +
+	"\x12estimated_end_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedEndTime\x12-\n" +
+	"\x12distance_remaining\x18\v \x01(\x01R\x11distanceRemaining\x12\x19\n" +
+	"\bevent_id\x18\f \x01(\tR\aeventId\"V\n" +
 	"\x15DronePositionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x8a\x02\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"h\n" +
+	"\x0eTelemetryBatch\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12:\n" +
+	"\tpositions\x18\x02 \x03(\v2\x1c.flight.DronePositionRequestR\tpositions\"\x9d\x01\n" +
+	"\fTelemetryAck\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\x05R\baccepted\x12\x16\n" +
+	"\x06window\x18\x05 \x01(\x05R\x06window\"\xa5\x02\n" +
 	"\x16FlightCompletedRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12<\n" +
 	"\x0efinal_position\x18\x03 \x01(\v2\x15.flight.DronePositionR\rfinalPosition\x12C\n" +
 	"\x0fcompletion_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecompletionTime\x12+\n" +
-	"\x11completion_status\x18\x05 \x01(\tR\x10completionStatus\"X\n" +
+	"\x11completion_status\x18\x05 \x01(\tR\x10completionStatus\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\tR\aeventId\"X\n" +
 	"\x17FlightCompletedResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x9d\x03\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xb8\x03\n" +
 	"\x1aRestrictedZoneAlertRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x1b\n" +
@@ -1259,30 +2077,82 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\bdistance\x18\b \x01(\x01R\bdistance\x12<\n" +
 	"\x0edrone_position\x18\t \x01(\v2\x15.flight.DronePositionR\rdronePosition\x128\n" +
 	"\ttimestamp\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\\\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bevent_id\x18\v \x01(\tR\aeventId\"\\\n" +
 	"\x1bRestrictedZoneAlertResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xf3\x01\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x8e\x02\n" +
 	"\x13FlightPausedRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12<\n" +
 	"\x0epause_position\x18\x03 \x01(\v2\x15.flight.DronePositionR\rpausePosition\x129\n" +
 	"\n" +
 	"pause_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tpauseTime\x12!\n" +
-	"\fpause_reason\x18\x05 \x01(\tR\vpauseReason\"U\n" +
+	"\fpause_reason\x18\x05 \x01(\tR\vpauseReason\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\tR\aeventId\"U\n" +
 	"\x14FlightPausedResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xfa\x01\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x95\x02\n" +
 	"\x14FlightResumedRequest\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12>\n" +
 	"\x0fresume_position\x18\x03 \x01(\v2\x15.flight.DronePositionR\x0eresumePosition\x12;\n" +
 	"\vresume_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"resumeTime\x12#\n" +
-	"\rresume_reason\x18\x05 \x01(\tR\fresumeReason\"V\n" +
+	"\rresume_reason\x18\x05 \x01(\tR\fresumeReason\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\tR\aeventId\"V\n" +
 	"\x15FlightResumedResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xba\x01\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xbc\x04\n" +
+	"\x16TrafficConflictRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
+	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x126\n" +
+	"\x17intruder_application_id\x18\x03 \x01(\x05R\x15intruderApplicationId\x12*\n" +
+	"\x11intruder_drone_id\x18\x04 \x01(\x05R\x0fintruderDroneId\x12\x1f\n" +
+	"\valert_level\x18\x05 \x01(\tR\n" +
+	"alertLevel\x123\n" +
+	"\x15horizontal_separation\x18\x06 \x01(\x01R\x14horizontalSeparation\x12/\n" +
+	"\x13vertical_separation\x18\a \x01(\x01R\x12verticalSeparation\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\b \x01(\tR\n" +
+	"resolution\x12<\n" +
+	"\x0edrone_position\x18\t \x01(\v2\x15.flight.DronePositionR\rdronePosition\x12B\n" +
+	"\x11intruder_position\x18\n" +
+	" \x01(\v2\x15.flight.DronePositionR\x10intruderPosition\x128\n" +
+	"\ttimestamp\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bevent_id\x18\f \x01(\tR\aeventId\"X\n" +
+	"\x17TrafficConflictResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xd8\x03\n" +
+	"\x15RouteDeviationRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
+	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12%\n" +
+	"\x0edeviation_type\x18\x03 \x01(\tR\rdeviationType\x12\x1f\n" +
+	"\valert_level\x18\x04 \x01(\tR\n" +
+	"alertLevel\x12+\n" +
+	"\x11lateral_deviation\x18\x05 \x01(\x01R\x10lateralDeviation\x12-\n" +
+	"\x12vertical_deviation\x18\x06 \x01(\x01R\x11verticalDeviation\x124\n" +
+	"\x16time_deviation_seconds\x18\a \x01(\x01R\x14timeDeviationSeconds\x12\x10\n" +
+	"\x03leg\x18\b \x01(\x05R\x03leg\x12<\n" +
+	"\x0edrone_position\x18\t \x01(\v2\x15.flight.DronePositionR\rdronePosition\x128\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bevent_id\x18\v \x01(\tR\aeventId\"W\n" +
+	"\x16RouteDeviationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xce\x02\n" +
+	"\x15WaypointActionRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
+	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x1a\n" +
+	"\bwaypoint\x18\x03 \x01(\x05R\bwaypoint\x12.\n" +
+	"\x06action\x18\x04 \x01(\v2\x16.flight.WaypointActionR\x06action\x12\x14\n" +
+	"\x05phase\x18\x05 \x01(\tR\x05phase\x12<\n" +
+	"\x0edrone_position\x18\x06 \x01(\v2\x15.flight.DronePositionR\rdronePosition\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bevent_id\x18\b \x01(\tR\aeventId\"W\n" +
+	"\x16WaypointActionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xec\x01\n" +
 	"\n" +
 	"RoutePoint\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
@@ -1291,7 +2161,14 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\baltitude\x18\x04 \x01(\x01R\baltitude\x12\x1f\n" +
 	"\vpoint_order\x18\x05 \x01(\x05R\n" +
 	"pointOrder\x12%\n" +
-	"\x0eapplication_id\x18\x06 \x01(\x05R\rapplicationId\"\xb8\x02\n" +
+	"\x0eapplication_id\x18\x06 \x01(\x05R\rapplicationId\x120\n" +
+	"\aactions\x18\a \x03(\v2\x16.flight.WaypointActionR\aactions\"\x82\x01\n" +
+	"\x0eWaypointAction\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\aseconds\x18\x02 \x01(\x01R\aseconds\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x01R\x06radius\x12\x14\n" +
+	"\x05turns\x18\x04 \x01(\x01R\x05turns\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\x01R\x05speed\"\xb8\x02\n" +
 	"\rDronePosition\x12%\n" +
 	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x19\n" +
 	"\bdrone_id\x18\x02 \x01(\x05R\adroneId\x12\x1a\n" +
@@ -1301,7 +2178,7 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\x05speed\x18\x06 \x01(\x01R\x05speed\x12\x18\n" +
 	"\aheading\x18\a \x01(\x01R\aheading\x12%\n" +
 	"\x0eroute_progress\x18\b \x01(\x01R\rrouteProgress\x128\n" +
-	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp2\xfd\x04\n" +
+	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp2\xca\a\n" +
 	"\x19FlightNotificationService\x12O\n" +
 	"\x12NotifyStatusUpdate\x12\x1b.flight.StatusUpdateRequest\x1a\x1c.flight.StatusUpdateResponse\x12R\n" +
 	"\x13NotifyFlightStarted\x12\x1c.flight.FlightStartedRequest\x1a\x1d.flight.FlightStartedResponse\x12R\n" +
@@ -1309,7 +2186,11 @@ const file_proto_fly_service_proto_rawDesc = "" +
 	"\x15NotifyFlightCompleted\x12\x1e.flight.FlightCompletedRequest\x1a\x1f.flight.FlightCompletedResponse\x12h\n" +
 	"\x1dNotifyRestrictedZoneProximity\x12\".flight.RestrictedZoneAlertRequest\x1a#.flight.RestrictedZoneAlertResponse\x12O\n" +
 	"\x12NotifyFlightPaused\x12\x1b.flight.FlightPausedRequest\x1a\x1c.flight.FlightPausedResponse\x12R\n" +
-	"\x13NotifyFlightResumed\x12\x1c.flight.FlightResumedRequest\x1a\x1d.flight.FlightResumedResponseB\x0eZ\fproto/flightb\x06proto3"
+	"\x13NotifyFlightResumed\x12\x1c.flight.FlightResumedRequest\x1a\x1d.flight.FlightResumedResponse\x12X\n" +
+	"\x15NotifyTrafficConflict\x12\x1e.flight.TrafficConflictRequest\x1a\x1f.flight.TrafficConflictResponse\x12U\n" +
+	"\x14NotifyRouteDeviation\x12\x1d.flight.RouteDeviationRequest\x1a\x1e.flight.RouteDeviationResponse\x12U\n" +
+	"\x14NotifyWaypointAction\x12\x1d.flight.WaypointActionRequest\x1a\x1e.flight.WaypointActionResponse\x12C\n" +
+	"\x0fStreamTelemetry\x12\x16.flight.TelemetryBatch\x1a\x14.flight.TelemetryAck(\x010\x01B\x0eZ\fproto/flightb\x06proto3"
 
 var (
 	file_proto_fly_service_proto_rawDescOnce sync.Once
@@ -1323,7 +2204,7 @@ func file_proto_fly_service_proto_rawDescGZIP() []byte {
 	return file_proto_fly_service_proto_rawDescData
 }
 
-var file_proto_fly_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_fly_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_fly_service_proto_goTypes = []any{
 	(*StatusUpdateRequest)(nil),         // 0: flight.StatusUpdateRequest
 	(*StatusUpdateResponse)(nil),        // 1: flight.StatusUpdateResponse
@@ -1331,53 +2212,81 @@ var file_proto_fly_service_proto_goTypes = []any{
 	(*FlightStartedResponse)(nil),       // 3: flight.FlightStartedResponse
 	(*DronePositionRequest)(nil),        // 4: flight.DronePositionRequest
 	(*DronePositionResponse)(nil),       // 5: flight.DronePositionResponse
-	(*FlightCompletedRequest)(nil),      // 6: flight.FlightCompletedRequest
-	(*FlightCompletedResponse)(nil),     // 7: flight.FlightCompletedResponse
-	(*RestrictedZoneAlertRequest)(nil),  // 8: flight.RestrictedZoneAlertRequest
-	(*RestrictedZoneAlertResponse)(nil), // 9: flight.RestrictedZoneAlertResponse
-	(*FlightPausedRequest)(nil),         // 10: flight.FlightPausedRequest
-	(*FlightPausedResponse)(nil),        // 11: flight.FlightPausedResponse
-	(*FlightResumedRequest)(nil),        // 12: flight.FlightResumedRequest
-	(*FlightResumedResponse)(nil),       // 13: flight.FlightResumedResponse
-	(*RoutePoint)(nil),                  // 14: flight.RoutePoint
-	(*DronePosition)(nil),               // 15: flight.DronePosition
-	(*timestamppb.Timestamp)(nil),       // 16: google.protobuf.Timestamp
+	(*TelemetryBatch)(nil),              // 6: flight.TelemetryBatch
+	(*TelemetryAck)(nil),                // 7: flight.TelemetryAck
+	(*FlightCompletedRequest)(nil),      // 8: flight.FlightCompletedRequest
+	(*FlightCompletedResponse)(nil),     // 9: flight.FlightCompletedResponse
+	(*RestrictedZoneAlertRequest)(nil),  // 10: flight.RestrictedZoneAlertRequest
+	(*RestrictedZoneAlertResponse)(nil), // 11: flight.RestrictedZoneAlertResponse
+	(*FlightPausedRequest)(nil),         // 12: flight.FlightPausedRequest
+	(*FlightPausedResponse)(nil),        // 13: flight.FlightPausedResponse
+	(*FlightResumedRequest)(nil),        // 14: flight.FlightResumedRequest
+	(*FlightResumedResponse)(nil),       // 15: flight.FlightResumedResponse
+	(*TrafficConflictRequest)(nil),      // 16: flight.TrafficConflictRequest
+	(*TrafficConflictResponse)(nil),     // 17: flight.TrafficConflictResponse
+	(*RouteDeviationRequest)(nil),       // 18: flight.RouteDeviationRequest
+	(*RouteDeviationResponse)(nil),      // 19: flight.RouteDeviationResponse
+	(*WaypointActionRequest)(nil),       // 20: flight.WaypointActionRequest
+	(*WaypointActionResponse)(nil),      // 21: flight.WaypointActionResponse
+	(*RoutePoint)(nil),                  // 22: flight.RoutePoint
+	(*WaypointAction)(nil),              // 23: flight.WaypointAction
+	(*DronePosition)(nil),               // 24: flight.DronePosition
+	(*timestamppb.Timestamp)(nil),       // 25: google.protobuf.Timestamp
 }
 var file_proto_fly_service_proto_depIdxs = []int32{
-	16, // 0: flight.StatusUpdateRequest.timestamp:type_name -> google.protobuf.Timestamp
-	14, // 1: flight.FlightStartedRequest.route:type_name -> flight.RoutePoint
-	15, // 2: flight.FlightStartedRequest.current_position:type_name -> flight.DronePosition
-	16, // 3: flight.FlightStartedRequest.start_time:type_name -> google.protobuf.Timestamp
-	16, // 4: flight.FlightStartedRequest.estimated_end_time:type_name -> google.protobuf.Timestamp
-	16, // 5: flight.DronePositionRequest.timestamp:type_name -> google.protobuf.Timestamp
-	15, // 6: flight.FlightCompletedRequest.final_position:type_name -> flight.DronePosition
-	16, // 7: flight.FlightCompletedRequest.completion_time:type_name -> google.protobuf.Timestamp
-	15, // 8: flight.RestrictedZoneAlertRequest.drone_position:type_name -> flight.DronePosition
-	16, // 9: flight.RestrictedZoneAlertRequest.timestamp:type_name -> google.protobuf.Timestamp
-	15, // 10: flight.FlightPausedRequest.pause_position:type_name -> flight.DronePosition
-	16, // 11: flight.FlightPausedRequest.pause_time:type_name -> google.protobuf.Timestamp
-	15, // 12: flight.FlightResumedRequest.resume_position:type_name -> flight.DronePosition
-	16, // 13: flight.FlightResumedRequest.resume_time:type_name -> google.protobuf.Timestamp
-	16, // 14: flight.DronePosition.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 15: flight.FlightNotificationService.NotifyStatusUpdate:input_type -> flight.StatusUpdateRequest
-	2,  // 16: flight.FlightNotificationService.NotifyFlightStarted:input_type -> flight.FlightStartedRequest
-	4,  // 17: flight.FlightNotificationService.UpdateDronePosition:input_type -> flight.DronePositionRequest
-	6,  // 18: flight.FlightNotificationService.NotifyFlightCompleted:input_type -> flight.FlightCompletedRequest
-	8,  // 19: flight.FlightNotificationService.NotifyRestrictedZoneProximity:input_type -> flight.RestrictedZoneAlertRequest
-	10, // 20: flight.FlightNotificationService.NotifyFlightPaused:input_type -> flight.FlightPausedRequest
-	12, // 21: flight.FlightNotificationService.NotifyFlightResumed:input_type -> flight.FlightResumedRequest
-	1,  // 22: flight.FlightNotificationService.NotifyStatusUpdate:output_type -> flight.StatusUpdateResponse
-	3,  // 23: flight.FlightNotificationService.NotifyFlightStarted:output_type -> flight.FlightStartedResponse
-	5,  // 24: flight.FlightNotificationService.UpdateDronePosition:output_type -> flight.DronePositionResponse
-	7,  // 25: flight.FlightNotificationService.NotifyFlightCompleted:output_type -> flight.FlightCompletedResponse
-	9,  // 26: flight.FlightNotificationService.NotifyRestrictedZoneProximity:output_type -> flight.RestrictedZoneAlertResponse
-	11, // 27: flight.FlightNotificationService.NotifyFlightPaused:output_type -> flight.FlightPausedResponse
-	13, // 28: flight.FlightNotificationService.NotifyFlightResumed:output_type -> flight.FlightResumedResponse
-	22, // [22:29] is the sub-list for method output_type
-	15, // [15:22] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	25, // 0: flight.StatusUpdateRequest.timestamp:type_name -> google.protobuf.Timestamp
+	22, // 1: flight.FlightStartedRequest.route:type_name -> flight.RoutePoint
+	24, // 2: flight.FlightStartedRequest.current_position:type_name -> flight.DronePosition
+	25, // 3: flight.FlightStartedRequest.start_time:type_name -> google.protobuf.Timestamp
+	25, // 4: flight.FlightStartedRequest.estimated_end_time:type_name -> google.protobuf.Timestamp
+	25, // 5: flight.DronePositionRequest.timestamp:type_name -> google.protobuf.Timestamp
+	25, // 6: flight.DronePositionRequest.estimated_end_time:type_name -> google.protobuf.Timestamp
+	4,  // 7: flight.TelemetryBatch.positions:type_name -> flight.DronePositionRequest
+	24, // 8: flight.FlightCompletedRequest.final_position:type_name -> flight.DronePosition
+	25, // 9: flight.FlightCompletedRequest.completion_time:type_name -> google.protobuf.Timestamp
+	24, // 10: flight.RestrictedZoneAlertRequest.drone_position:type_name -> flight.DronePosition
+	25, // 11: flight.RestrictedZoneAlertRequest.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 12: flight.FlightPausedRequest.pause_position:type_name -> flight.DronePosition
+	25, // 13: flight.FlightPausedRequest.pause_time:type_name -> google.protobuf.Timestamp
+	24, // 14: flight.FlightResumedRequest.resume_position:type_name -> flight.DronePosition
+	25, // 15: flight.FlightResumedRequest.resume_time:type_name -> google.protobuf.Timestamp
+	24, // 16: flight.TrafficConflictRequest.drone_position:type_name -> flight.DronePosition
+	24, // 17: flight.TrafficConflictRequest.intruder_position:type_name -> flight.DronePosition
+	25, // 18: flight.TrafficConflictRequest.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 19: flight.RouteDeviationRequest.drone_position:type_name -> flight.DronePosition
+	25, // 20: flight.RouteDeviationRequest.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 21: flight.WaypointActionRequest.action:type_name -> flight.WaypointAction
+	24, // 22: flight.WaypointActionRequest.drone_position:type_name -> flight.DronePosition
+	25, // 23: flight.WaypointActionRequest.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 24: flight.RoutePoint.actions:type_name -> flight.WaypointAction
+	25, // 25: flight.DronePosition.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 26: flight.FlightNotificationService.NotifyStatusUpdate:input_type -> flight.StatusUpdateRequest
+	2,  // 27: flight.FlightNotificationService.NotifyFlightStarted:input_type -> flight.FlightStartedRequest
+	4,  // 28: flight.FlightNotificationService.UpdateDronePosition:input_type -> flight.DronePositionRequest
+	8,  // 29: flight.FlightNotificationService.NotifyFlightCompleted:input_type -> flight.FlightCompletedRequest
+	10, // 30: flight.FlightNotificationService.NotifyRestrictedZoneProximity:input_type -> flight.RestrictedZoneAlertRequest
+	12, // 31: flight.FlightNotificationService.NotifyFlightPaused:input_type -> flight.FlightPausedRequest
+	14, // 32: flight.FlightNotificationService.NotifyFlightResumed:input_type -> flight.FlightResumedRequest
+	16, // 33: flight.FlightNotificationService.NotifyTrafficConflict:input_type -> flight.TrafficConflictRequest
+	18, // 34: flight.FlightNotificationService.NotifyRouteDeviation:input_type -> flight.RouteDeviationRequest
+	20, // 35: flight.FlightNotificationService.NotifyWaypointAction:input_type -> flight.WaypointActionRequest
+	6,  // 36: flight.FlightNotificationService.StreamTelemetry:input_type -> flight.TelemetryBatch
+	1,  // 37: flight.FlightNotificationService.NotifyStatusUpdate:output_type -> flight.StatusUpdateResponse
+	3,  // 38: flight.FlightNotificationService.NotifyFlightStarted:output_type -> flight.FlightStartedResponse
+	5,  // 39: flight.FlightNotificationService.UpdateDronePosition:output_type -> flight.DronePositionResponse
+	9,  // 40: flight.FlightNotificationService.NotifyFlightCompleted:output_type -> flight.FlightCompletedResponse
+	11, // 41: flight.FlightNotificationService.NotifyRestrictedZoneProximity:output_type -> flight.RestrictedZoneAlertResponse
+	13, // 42: flight.FlightNotificationService.NotifyFlightPaused:output_type -> flight.FlightPausedResponse
+	15, // 43: flight.FlightNotificationService.NotifyFlightResumed:output_type -> flight.FlightResumedResponse
+	17, // 44: flight.FlightNotificationService.NotifyTrafficConflict:output_type -> flight.TrafficConflictResponse
+	19, // 45: flight.FlightNotificationService.NotifyRouteDeviation:output_type -> flight.RouteDeviationResponse
+	21, // 46: flight.FlightNotificationService.NotifyWaypointAction:output_type -> flight.WaypointActionResponse
+	7,  // 47: flight.FlightNotificationService.StreamTelemetry:output_type -> flight.TelemetryAck
+	37, // [37:48] is the sub-list for method output_type
+	26, // [26:37] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_fly_service_proto_init() }
@@ -1391,7 +2300,7 @@ func file_proto_fly_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_fly_service_proto_rawDesc), len(file_proto_fly_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
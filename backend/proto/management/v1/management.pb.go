@@ -0,0 +1,1656 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: proto/management/v1/management.proto
+
+package managementv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SignInRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignInRequest) Reset() {
+	*x = SignInRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInRequest) ProtoMessage() {}
+
+func (x *SignInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInRequest.ProtoReflect.Descriptor instead.
+func (*SignInRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignInRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *SignInRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type SignUpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Firstname     string                 `protobuf:"bytes,1,opt,name=firstname,proto3" json:"firstname,omitempty"`
+	Lastname      string                 `protobuf:"bytes,2,opt,name=lastname,proto3" json:"lastname,omitempty"`
+	Middlename    string                 `protobuf:"bytes,3,opt,name=middlename,proto3" json:"middlename,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignUpRequest) Reset() {
+	*x = SignUpRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUpRequest) ProtoMessage() {}
+
+func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUpRequest.ProtoReflect.Descriptor instead.
+func (*SignUpRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignUpRequest) GetFirstname() string {
+	if x != nil {
+		return x.Firstname
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetLastname() string {
+	if x != nil {
+		return x.Lastname
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetMiddlename() string {
+	if x != nil {
+		return x.Middlename
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type TokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenResponse) Reset() {
+	*x = TokenResponse{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenResponse) ProtoMessage() {}
+
+func (x *TokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenResponse.ProtoReflect.Descriptor instead.
+func (*TokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type Pilot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PilotId       int32                  `protobuf:"varint,1,opt,name=pilot_id,json=pilotId,proto3" json:"pilot_id,omitempty"`
+	Firstname     string                 `protobuf:"bytes,2,opt,name=firstname,proto3" json:"firstname,omitempty"`
+	Lastname      string                 `protobuf:"bytes,3,opt,name=lastname,proto3" json:"lastname,omitempty"`
+	Middlename    string                 `protobuf:"bytes,4,opt,name=middlename,proto3" json:"middlename,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pilot) Reset() {
+	*x = Pilot{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pilot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pilot) ProtoMessage() {}
+
+func (x *Pilot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pilot.ProtoReflect.Descriptor instead.
+func (*Pilot) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pilot) GetPilotId() int32 {
+	if x != nil {
+		return x.PilotId
+	}
+	return 0
+}
+
+func (x *Pilot) GetFirstname() string {
+	if x != nil {
+		return x.Firstname
+	}
+	return ""
+}
+
+func (x *Pilot) GetLastname() string {
+	if x != nil {
+		return x.Lastname
+	}
+	return ""
+}
+
+func (x *Pilot) GetMiddlename() string {
+	if x != nil {
+		return x.Middlename
+	}
+	return ""
+}
+
+func (x *Pilot) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Pilot) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Drone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int32                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ModelName     string                 `protobuf:"bytes,3,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	BrandName     string                 `protobuf:"bytes,4,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Drone) Reset() {
+	*x = Drone{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Drone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Drone) ProtoMessage() {}
+
+func (x *Drone) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Drone.ProtoReflect.Descriptor instead.
+func (*Drone) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Drone) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *Drone) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Drone) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *Drone) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+type CreateDroneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber  string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ModelName     string                 `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	BrandName     string                 `protobuf:"bytes,3,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDroneRequest) Reset() {
+	*x = CreateDroneRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDroneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDroneRequest) ProtoMessage() {}
+
+func (x *CreateDroneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDroneRequest.ProtoReflect.Descriptor instead.
+func (*CreateDroneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateDroneRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *CreateDroneRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *CreateDroneRequest) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+type GetDroneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int32                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDroneRequest) Reset() {
+	*x = GetDroneRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDroneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDroneRequest) ProtoMessage() {}
+
+func (x *GetDroneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDroneRequest.ProtoReflect.Descriptor instead.
+func (*GetDroneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetDroneRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+// Страница списка задается так же, как в REST: limit от 1 до 100 (0 — 50),
+// cursor — next_cursor предыдущего ответа, order — asc или desc.
+type ListDronesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDronesRequest) Reset() {
+	*x = ListDronesRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDronesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDronesRequest) ProtoMessage() {}
+
+func (x *ListDronesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDronesRequest.ProtoReflect.Descriptor instead.
+func (*ListDronesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListDronesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDronesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDronesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListDronesRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+type ListDronesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drones        []*Drone               `protobuf:"bytes,1,rep,name=drones,proto3" json:"drones,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDronesResponse) Reset() {
+	*x = ListDronesResponse{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDronesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDronesResponse) ProtoMessage() {}
+
+func (x *ListDronesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDronesResponse.ProtoReflect.Descriptor instead.
+func (*ListDronesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDronesResponse) GetDrones() []*Drone {
+	if x != nil {
+		return x.Drones
+	}
+	return nil
+}
+
+func (x *ListDronesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DeleteDroneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int32                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDroneRequest) Reset() {
+	*x = DeleteDroneRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDroneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDroneRequest) ProtoMessage() {}
+
+func (x *DeleteDroneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDroneRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDroneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteDroneRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+type Application struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,8,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Application) Reset() {
+	*x = Application{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Application) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Application) ProtoMessage() {}
+
+func (x *Application) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Application.ProtoReflect.Descriptor instead.
+func (*Application) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Application) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *Application) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Application) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Application) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Application) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Application) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Application) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Application) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+type CreateApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	DroneId       int32                  `protobuf:"varint,3,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,6,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Tested        bool                   `protobuf:"varint,7,opt,name=tested,proto3" json:"tested,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApplicationRequest) Reset() {
+	*x = CreateApplicationRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApplicationRequest) ProtoMessage() {}
+
+func (x *CreateApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApplicationRequest.ProtoReflect.Descriptor instead.
+func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateApplicationRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *CreateApplicationRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateApplicationRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateApplicationRequest) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *CreateApplicationRequest) GetTested() bool {
+	if x != nil {
+		return x.Tested
+	}
+	return false
+}
+
+// pilot_id = 0 — заявки текущего пилота. Чужие заявки может запросить
+// только диспетчер. sort — id, start_date или created_at; from и to
+// ограничивают дату начала и задаются как start_date.
+type ListApplicationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PilotId       int32                  `protobuf:"varint,1,opt,name=pilot_id,json=pilotId,proto3" json:"pilot_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order         string                 `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	Status        []string               `protobuf:"bytes,6,rep,name=status,proto3" json:"status,omitempty"`
+	DroneId       int32                  `protobuf:"varint,7,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	From          string                 `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsRequest) Reset() {
+	*x = ListApplicationsRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsRequest) ProtoMessage() {}
+
+func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsRequest.ProtoReflect.Descriptor instead.
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListApplicationsRequest) GetPilotId() int32 {
+	if x != nil {
+		return x.PilotId
+	}
+	return 0
+}
+
+func (x *ListApplicationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListApplicationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetStatus() []string {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListApplicationsRequest) GetDroneId() int32 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *ListApplicationsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListApplicationsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ListApplicationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applications  []*Application         `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsResponse) Reset() {
+	*x = ListApplicationsResponse{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsResponse) ProtoMessage() {}
+
+func (x *ListApplicationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsResponse.ProtoReflect.Descriptor instead.
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListApplicationsResponse) GetApplications() []*Application {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+func (x *ListApplicationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DeleteApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int32                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApplicationRequest) Reset() {
+	*x = DeleteApplicationRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApplicationRequest) ProtoMessage() {}
+
+func (x *DeleteApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApplicationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteApplicationRequest) GetApplicationId() int32 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+type Zone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        int32                  `protobuf:"varint,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,5,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Radius        int32                  `protobuf:"varint,6,opt,name=radius,proto3" json:"radius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Zone) Reset() {
+	*x = Zone{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Zone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Zone) ProtoMessage() {}
+
+func (x *Zone) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Zone.ProtoReflect.Descriptor instead.
+func (*Zone) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Zone) GetZoneId() int32 {
+	if x != nil {
+		return x.ZoneId
+	}
+	return 0
+}
+
+func (x *Zone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Zone) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Zone) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Zone) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *Zone) GetRadius() int32 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+type CreateZoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      float64                `protobuf:"fixed64,4,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Radius        int32                  `protobuf:"varint,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateZoneRequest) Reset() {
+	*x = CreateZoneRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateZoneRequest) ProtoMessage() {}
+
+func (x *CreateZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateZoneRequest.ProtoReflect.Descriptor instead.
+func (*CreateZoneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateZoneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateZoneRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateZoneRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateZoneRequest) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *CreateZoneRequest) GetRadius() int32 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+// bbox оставляет зоны, центр которых лежит в прямоугольнике.
+type ListZonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Bbox          *BoundingBox           `protobuf:"bytes,5,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListZonesRequest) Reset() {
+	*x = ListZonesRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesRequest) ProtoMessage() {}
+
+func (x *ListZonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesRequest.ProtoReflect.Descriptor instead.
+func (*ListZonesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListZonesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListZonesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListZonesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListZonesRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListZonesRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type ListZonesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zones         []*Zone                `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListZonesResponse) Reset() {
+	*x = ListZonesResponse{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesResponse) ProtoMessage() {}
+
+func (x *ListZonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesResponse.ProtoReflect.Descriptor instead.
+func (*ListZonesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListZonesResponse) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *ListZonesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DeleteZoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        int32                  `protobuf:"varint,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteZoneRequest) Reset() {
+	*x = DeleteZoneRequest{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteZoneRequest) ProtoMessage() {}
+
+func (x *DeleteZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteZoneRequest.ProtoReflect.Descriptor instead.
+func (*DeleteZoneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteZoneRequest) GetZoneId() int32 {
+	if x != nil {
+		return x.ZoneId
+	}
+	return 0
+}
+
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLatitude   float64                `protobuf:"fixed64,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude  float64                `protobuf:"fixed64,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude   float64                `protobuf:"fixed64,3,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude  float64                `protobuf:"fixed64,4,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_proto_management_v1_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_v1_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_proto_management_v1_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BoundingBox) GetMinLatitude() float64 {
+	if x != nil {
+		return x.MinLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinLongitude() float64 {
+	if x != nil {
+		return x.MinLongitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLatitude() float64 {
+	if x != nil {
+		return x.MaxLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLongitude() float64 {
+	if x != nil {
+		return x.MaxLongitude
+	}
+	return 0
+}
+
+var File_proto_management_v1_management_proto protoreflect.FileDescriptor
+
+const file_proto_management_v1_management_proto_rawDesc = "" +
+	"\n" +
+	"$proto/management/v1/management.proto\x12\rmanagement.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"A\n" +
+	"\rSignInRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x9b\x01\n" +
+	"\rSignUpRequest\x12\x1c\n" +
+	"\tfirstname\x18\x01 \x01(\tR\tfirstname\x12\x1a\n" +
+	"\blastname\x18\x02 \x01(\tR\blastname\x12\x1e\n" +
+	"\n" +
+	"middlename\x18\x03 \x01(\tR\n" +
+	"middlename\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\"2\n" +
+	"\rTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\xa6\x01\n" +
+	"\x05Pilot\x12\x19\n" +
+	"\bpilot_id\x18\x01 \x01(\x05R\apilotId\x12\x1c\n" +
+	"\tfirstname\x18\x02 \x01(\tR\tfirstname\x12\x1a\n" +
+	"\blastname\x18\x03 \x01(\tR\blastname\x12\x1e\n" +
+	"\n" +
+	"middlename\x18\x04 \x01(\tR\n" +
+	"middlename\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\"\x85\x01\n" +
+	"\x05Drone\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x05R\adroneId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x03 \x01(\tR\tmodelName\x12\x1d\n" +
+	"\n" +
+	"brand_name\x18\x04 \x01(\tR\tbrandName\"w\n" +
+	"\x12CreateDroneRequest\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x1d\n" +
+	"\n" +
+	"brand_name\x18\x03 \x01(\tR\tbrandName\",\n" +
+	"\x0fGetDroneRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x05R\adroneId\"k\n" +
+	"\x11ListDronesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\"c\n" +
+	"\x12ListDronesResponse\x12,\n" +
+	"\x06drones\x18\x01 \x03(\v2\x14.management.v1.DroneR\x06drones\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"/\n" +
+	"\x12DeleteDroneRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x05R\adroneId\"\xa1\x02\n" +
+	"\vApplication\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rserial_number\x18\x05 \x01(\tR\fserialNumber\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\b \x01(\x01R\baltitude\"\xdd\x01\n" +
+	"\x18CreateApplicationRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x19\n" +
+	"\bdrone_id\x18\x03 \x01(\x05R\adroneId\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x06 \x01(\x01R\baltitude\x12\x16\n" +
+	"\x06tested\x18\a \x01(\bR\x06tested\"\xe3\x01\n" +
+	"\x17ListApplicationsRequest\x12\x19\n" +
+	"\bpilot_id\x18\x01 \x01(\x05R\apilotId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x05 \x01(\tR\x05order\x12\x16\n" +
+	"\x06status\x18\x06 \x03(\tR\x06status\x12\x19\n" +
+	"\bdrone_id\x18\a \x01(\x05R\adroneId\x12\x12\n" +
+	"\x04from\x18\b \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\t \x01(\tR\x02to\"{\n" +
+	"\x18ListApplicationsResponse\x12>\n" +
+	"\fapplications\x18\x01 \x03(\v2\x1a.management.v1.ApplicationR\fapplications\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"A\n" +
+	"\x18DeleteApplicationRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x05R\rapplicationId\"\xa1\x01\n" +
+	"\x04Zone\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\x05R\x06zoneId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x05 \x01(\x01R\baltitude\x12\x16\n" +
+	"\x06radius\x18\x06 \x01(\x05R\x06radius\"\x95\x01\n" +
+	"\x11CreateZoneRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x04 \x01(\x01R\baltitude\x12\x16\n" +
+	"\x06radius\x18\x05 \x01(\x05R\x06radius\"\x9a\x01\n" +
+	"\x10ListZonesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x12.\n" +
+	"\x04bbox\x18\x05 \x01(\v2\x1a.management.v1.BoundingBoxR\x04bbox\"_\n" +
+	"\x11ListZonesResponse\x12)\n" +
+	"\x05zones\x18\x01 \x03(\v2\x13.management.v1.ZoneR\x05zones\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\",\n" +
+	"\x11DeleteZoneRequest\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\x05R\x06zoneId\"\x9d\x01\n" +
+	"\vBoundingBox\x12!\n" +
+	"\fmin_latitude\x18\x01 \x01(\x01R\vminLatitude\x12#\n" +
+	"\rmin_longitude\x18\x02 \x01(\x01R\fminLongitude\x12!\n" +
+	"\fmax_latitude\x18\x03 \x01(\x01R\vmaxLatitude\x12#\n" +
+	"\rmax_longitude\x18\x04 \x01(\x01R\fmaxLongitude2\xf0\a\n" +
+	"\x11ManagementService\x12D\n" +
+	"\x06SignIn\x12\x1c.management.v1.SignInRequest\x1a\x1c.management.v1.TokenResponse\x12D\n" +
+	"\x06SignUp\x12\x1c.management.v1.SignUpRequest\x1a\x1c.management.v1.TokenResponse\x128\n" +
+	"\bGetPilot\x12\x16.google.protobuf.Empty\x1a\x14.management.v1.Pilot\x12H\n" +
+	"\vCreateDrone\x12!.management.v1.CreateDroneRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\bGetDrone\x12\x1e.management.v1.GetDroneRequest\x1a\x14.management.v1.Drone\x12Q\n" +
+	"\n" +
+	"ListDrones\x12 .management.v1.ListDronesRequest\x1a!.management.v1.ListDronesResponse\x12H\n" +
+	"\vDeleteDrone\x12!.management.v1.DeleteDroneRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\x11CreateApplication\x12'.management.v1.CreateApplicationRequest\x1a\x16.google.protobuf.Empty\x12c\n" +
+	"\x10ListApplications\x12&.management.v1.ListApplicationsRequest\x1a'.management.v1.ListApplicationsResponse\x12T\n" +
+	"\x11DeleteApplication\x12'.management.v1.DeleteApplicationRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\n" +
+	"CreateZone\x12 .management.v1.CreateZoneRequest\x1a\x13.management.v1.Zone\x12N\n" +
+	"\tListZones\x12\x1f.management.v1.ListZonesRequest\x1a .management.v1.ListZonesResponse\x12F\n" +
+	"\n" +
+	"DeleteZone\x12 .management.v1.DeleteZoneRequest\x1a\x16.google.protobuf.EmptyB\"Z proto/management/v1;managementv1b\x06proto3"
+
+var (
+	file_proto_management_v1_management_proto_rawDescOnce sync.Once
+	file_proto_management_v1_management_proto_rawDescData []byte
+)
+
+func file_proto_management_v1_management_proto_rawDescGZIP() []byte {
+	file_proto_management_v1_management_proto_rawDescOnce.Do(func() {
+		file_proto_management_v1_management_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_management_v1_management_proto_rawDesc), len(file_proto_management_v1_management_proto_rawDesc)))
+	})
+	return file_proto_management_v1_management_proto_rawDescData
+}
+
+var file_proto_management_v1_management_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_management_v1_management_proto_goTypes = []any{
+	(*SignInRequest)(nil),            // 0: management.v1.SignInRequest
+	(*SignUpRequest)(nil),            // 1: management.v1.SignUpRequest
+	(*TokenResponse)(nil),            // 2: management.v1.TokenResponse
+	(*Pilot)(nil),                    // 3: management.v1.Pilot
+	(*Drone)(nil),                    // 4: management.v1.Drone
+	(*CreateDroneRequest)(nil),       // 5: management.v1.CreateDroneRequest
+	(*GetDroneRequest)(nil),          // 6: management.v1.GetDroneRequest
+	(*ListDronesRequest)(nil),        // 7: management.v1.ListDronesRequest
+	(*ListDronesResponse)(nil),       // 8: management.v1.ListDronesResponse
+	(*DeleteDroneRequest)(nil),       // 9: management.v1.DeleteDroneRequest
+	(*Application)(nil),              // 10: management.v1.Application
+	(*CreateApplicationRequest)(nil), // 11: management.v1.CreateApplicationRequest
+	(*ListApplicationsRequest)(nil),  // 12: management.v1.ListApplicationsRequest
+	(*ListApplicationsResponse)(nil), // 13: management.v1.ListApplicationsResponse
+	(*DeleteApplicationRequest)(nil), // 14: management.v1.DeleteApplicationRequest
+	(*Zone)(nil),                     // 15: management.v1.Zone
+	(*CreateZoneRequest)(nil),        // 16: management.v1.CreateZoneRequest
+	(*ListZonesRequest)(nil),         // 17: management.v1.ListZonesRequest
+	(*ListZonesResponse)(nil),        // 18: management.v1.ListZonesResponse
+	(*DeleteZoneRequest)(nil),        // 19: management.v1.DeleteZoneRequest
+	(*BoundingBox)(nil),              // 20: management.v1.BoundingBox
+	(*timestamppb.Timestamp)(nil),    // 21: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),            // 22: google.protobuf.Empty
+}
+var file_proto_management_v1_management_proto_depIdxs = []int32{
+	4,  // 0: management.v1.ListDronesResponse.drones:type_name -> management.v1.Drone
+	21, // 1: management.v1.Application.created_at:type_name -> google.protobuf.Timestamp
+	10, // 2: management.v1.ListApplicationsResponse.applications:type_name -> management.v1.Application
+	20, // 3: management.v1.ListZonesRequest.bbox:type_name -> management.v1.BoundingBox
+	15, // 4: management.v1.ListZonesResponse.zones:type_name -> management.v1.Zone
+	0,  // 5: management.v1.ManagementService.SignIn:input_type -> management.v1.SignInRequest
+	1,  // 6: management.v1.ManagementService.SignUp:input_type -> management.v1.SignUpRequest
+	22, // 7: management.v1.ManagementService.GetPilot:input_type -> google.protobuf.Empty
+	5,  // 8: management.v1.ManagementService.CreateDrone:input_type -> management.v1.CreateDroneRequest
+	6,  // 9: management.v1.ManagementService.GetDrone:input_type -> management.v1.GetDroneRequest
+	7,  // 10: management.v1.ManagementService.ListDrones:input_type -> management.v1.ListDronesRequest
+	9,  // 11: management.v1.ManagementService.DeleteDrone:input_type -> management.v1.DeleteDroneRequest
+	11, // 12: management.v1.ManagementService.CreateApplication:input_type -> management.v1.CreateApplicationRequest
+	12, // 13: management.v1.ManagementService.ListApplications:input_type -> management.v1.ListApplicationsRequest
+	14, // 14: management.v1.ManagementService.DeleteApplication:input_type -> management.v1.DeleteApplicationRequest
+	16, // 15: management.v1.ManagementService.CreateZone:input_type -> management.v1.CreateZoneRequest
+	17, // 16: management.v1.ManagementService.ListZones:input_type -> management.v1.ListZonesRequest
+	19, // 17: management.v1.ManagementService.DeleteZone:input_type -> management.v1.DeleteZoneRequest
+	2,  // 18: management.v1.ManagementService.SignIn:output_type -> management.v1.TokenResponse
+	2,  // 19: management.v1.ManagementService.SignUp:output_type -> management.v1.TokenResponse
+	3,  // 20: management.v1.ManagementService.GetPilot:output_type -> management.v1.Pilot
+	22, // 21: management.v1.ManagementService.CreateDrone:output_type -> google.protobuf.Empty
+	4,  // 22: management.v1.ManagementService.GetDrone:output_type -> management.v1.Drone
+	8,  // 23: management.v1.ManagementService.ListDrones:output_type -> management.v1.ListDronesResponse
+	22, // 24: management.v1.ManagementService.DeleteDrone:output_type -> google.protobuf.Empty
+	22, // 25: management.v1.ManagementService.CreateApplication:output_type -> google.protobuf.Empty
+	13, // 26: management.v1.ManagementService.ListApplications:output_type -> management.v1.ListApplicationsResponse
+	22, // 27: management.v1.ManagementService.DeleteApplication:output_type -> google.protobuf.Empty
+	15, // 28: management.v1.ManagementService.CreateZone:output_type -> management.v1.Zone
+	18, // 29: management.v1.ManagementService.ListZones:output_type -> management.v1.ListZonesResponse
+	22, // 30: management.v1.ManagementService.DeleteZone:output_type -> google.protobuf.Empty
+	18, // [18:31] is the sub-list for method output_type
+	5,  // [5:18] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_management_v1_management_proto_init() }
+func file_proto_management_v1_management_proto_init() {
+	if File_proto_management_v1_management_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_management_v1_management_proto_rawDesc), len(file_proto_management_v1_management_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_management_v1_management_proto_goTypes,
+		DependencyIndexes: file_proto_management_v1_management_proto_depIdxs,
+		MessageInfos:      file_proto_management_v1_management_proto_msgTypes,
+	}.Build()
+	File_proto_management_v1_management_proto = out.File
+	file_proto_management_v1_management_proto_goTypes = nil
+	file_proto_management_v1_management_proto_depIdxs = nil
+}
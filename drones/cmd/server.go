@@ -21,7 +21,7 @@ func main() {
 	}
 	defer repo.Close()
 
-	grpcClient, err := grpc.NewNotificationClient(cfg.GRPCServerAddress)
+	grpcClient, err := grpc.NewNotificationClient(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize gRPC client: %v", err)
 	}
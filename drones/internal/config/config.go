@@ -9,14 +9,47 @@ import (
 type Config struct {
 	DatabaseURL            string
 	GRPCServerAddress      string
+	GRPCAuthToken          string
+	GRPCTLS                bool
+	GRPCCAFile             string
+	GRPCCertFile           string
+	GRPCKeyFile            string
+	GRPCServerName         string
 	Port                   string
 	ProcessingDelay        time.Duration
 	PositionUpdateInterval time.Duration
 	FlightSpeedMS          float64
 
+	// GRPCInsecureDev разрешает подключаться к бэкенду без TLS и без токена.
+	// Только для локальной разработки.
+	GRPCInsecureDev bool
+
 	BaseLatitude  float64
 	BaseLongitude float64
 	BaseAltitude  float64
+
+	SeparationHorizontalM float64
+	SeparationVerticalM   float64
+	ConflictResolution    string
+	// ClimbRateMS — вертикальная скорость набора и снижения при разрешении climb
+	ClimbRateMS float64
+
+	ConformanceLateralM  float64
+	ConformanceVerticalM float64
+	ConformanceTime      time.Duration
+
+	TelemetryBatchSize     int
+	TelemetryFlushInterval time.Duration
+
+	OutboxPollInterval time.Duration
+	// OutboxMaxAttempts — после скольких неудачных попыток уведомление
+	// отбрасывается. 0 — повторять, пока бэкенд не станет доступен.
+	OutboxMaxAttempts int
+
+	CommandPollInterval time.Duration
+
+	// За сколько до начала вхождения регулярной заявки процессор начинает его проверку
+	SeriesValidationLead time.Duration
 }
 
 func Load() *Config {
@@ -27,11 +60,39 @@ func Load() *Config {
 	baseLon, _ := strconv.ParseFloat(getEnv("BASE_LONGITUDE", "71.41216"), 64)
 	baseAlt, _ := strconv.ParseFloat(getEnv("BASE_ALTITUDE", "0.0"), 64)
 
+	separationHorizontal, _ := strconv.ParseFloat(getEnv("SEPARATION_HORIZONTAL_M", "150.0"), 64)
+	separationVertical, _ := strconv.ParseFloat(getEnv("SEPARATION_VERTICAL_M", "30.0"), 64)
+	climbRate, _ := strconv.ParseFloat(getEnv("CLIMB_RATE_MS", "3.0"), 64)
+
+	conformanceLateral, _ := strconv.ParseFloat(getEnv("CONFORMANCE_LATERAL_M", "50.0"), 64)
+	conformanceVertical, _ := strconv.ParseFloat(getEnv("CONFORMANCE_VERTICAL_M", "20.0"), 64)
+	conformanceTime, _ := strconv.Atoi(getEnv("CONFORMANCE_TIME_SECONDS", "30"))
+
+	telemetryBatchSize, _ := strconv.Atoi(getEnv("TELEMETRY_BATCH_SIZE", "100"))
+	telemetryFlushInterval, _ := strconv.Atoi(getEnv("TELEMETRY_FLUSH_INTERVAL_MS", "250"))
+
+	outboxPollInterval, _ := strconv.Atoi(getEnv("OUTBOX_POLL_INTERVAL_MS", "1000"))
+	outboxMaxAttempts, _ := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "0"))
+
+	commandPollInterval, _ := strconv.Atoi(getEnv("COMMAND_POLL_INTERVAL_MS", "1000"))
+	seriesValidationLead, _ := strconv.Atoi(getEnv("SERIES_VALIDATION_LEAD_SECONDS", "60"))
+
+	grpcCAFile := getEnv("GRPC_CA_FILE", "")
+	grpcTLS, _ := strconv.ParseBool(getEnv("GRPC_TLS", "false"))
+	grpcInsecureDev, _ := strconv.ParseBool(getEnv("GRPC_INSECURE_DEV", "false"))
+
 	defaultDatabaseURL := "root:root@tcp(localhost:3306)/mydb"
 
 	return &Config{
 		DatabaseURL:            getEnv("DATABASE_URL", defaultDatabaseURL),
 		GRPCServerAddress:      getEnv("GRPC_SERVER_ADDRESS", "localhost:1234"),
+		GRPCAuthToken:          getEnv("GRPC_AUTH_TOKEN", ""),
+		GRPCTLS:                grpcTLS || grpcCAFile != "",
+		GRPCInsecureDev:        grpcInsecureDev,
+		GRPCCAFile:             grpcCAFile,
+		GRPCCertFile:           getEnv("GRPC_CERT_FILE", ""),
+		GRPCKeyFile:            getEnv("GRPC_KEY_FILE", ""),
+		GRPCServerName:         getEnv("GRPC_SERVER_NAME", ""),
 		Port:                   getEnv("PORT", "5051"),
 		ProcessingDelay:        time.Duration(processingDelay) * time.Second,
 		PositionUpdateInterval: time.Second,
@@ -39,6 +100,19 @@ func Load() *Config {
 		BaseLatitude:           baseLat,
 		BaseLongitude:          baseLon,
 		BaseAltitude:           baseAlt,
+		SeparationHorizontalM:  separationHorizontal,
+		SeparationVerticalM:    separationVertical,
+		ConflictResolution:     getEnv("CONFLICT_RESOLUTION", "none"),
+		ClimbRateMS:            climbRate,
+		ConformanceLateralM:    conformanceLateral,
+		ConformanceVerticalM:   conformanceVertical,
+		ConformanceTime:        time.Duration(conformanceTime) * time.Second,
+		TelemetryBatchSize:     telemetryBatchSize,
+		TelemetryFlushInterval: time.Duration(telemetryFlushInterval) * time.Millisecond,
+		OutboxPollInterval:     time.Duration(outboxPollInterval) * time.Millisecond,
+		OutboxMaxAttempts:      outboxMaxAttempts,
+		CommandPollInterval:    time.Duration(commandPollInterval) * time.Millisecond,
+		SeriesValidationLead:   time.Duration(seriesValidationLead) * time.Second,
 	}
 }
 
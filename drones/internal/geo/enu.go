@@ -0,0 +1,79 @@
+package geo
+
+import "math"
+
+// ENU — локальная касательная система координат (восток, север, вверх)
+// с началом в заданной точке эллипсоида WGS-84.
+type ENU struct {
+	originX, originY, originZ float64
+	sinLat, cosLat            float64
+	sinLon, cosLon            float64
+}
+
+func NewENU(lat, lon, alt float64) *ENU {
+	x, y, z := toECEF(lat, lon, alt)
+	sinLat, cosLat := math.Sincos(toRadians(lat))
+	sinLon, cosLon := math.Sincos(toRadians(lon))
+
+	return &ENU{
+		originX: x,
+		originY: y,
+		originZ: z,
+		sinLat:  sinLat,
+		cosLat:  cosLat,
+		sinLon:  sinLon,
+		cosLon:  cosLon,
+	}
+}
+
+// Forward переводит геодезические координаты в метры east/north/up относительно начала.
+func (e *ENU) Forward(lat, lon, alt float64) (float64, float64, float64) {
+	x, y, z := toECEF(lat, lon, alt)
+	dx, dy, dz := x-e.originX, y-e.originY, z-e.originZ
+
+	east := -e.sinLon*dx + e.cosLon*dy
+	north := -e.sinLat*e.cosLon*dx - e.sinLat*e.sinLon*dy + e.cosLat*dz
+	up := e.cosLat*e.cosLon*dx + e.cosLat*e.sinLon*dy + e.sinLat*dz
+
+	return east, north, up
+}
+
+// Inverse переводит локальные координаты обратно в широту, долготу и высоту.
+func (e *ENU) Inverse(east, north, up float64) (float64, float64, float64) {
+	dx := -e.sinLon*east - e.sinLat*e.cosLon*north + e.cosLat*e.cosLon*up
+	dy := e.cosLon*east - e.sinLat*e.sinLon*north + e.cosLat*e.sinLon*up
+	dz := e.cosLat*north + e.sinLat*up
+
+	return fromECEF(e.originX+dx, e.originY+dy, e.originZ+dz)
+}
+
+func toECEF(lat, lon, alt float64) (float64, float64, float64) {
+	sinLat, cosLat := math.Sincos(toRadians(lat))
+	sinLon, cosLon := math.Sincos(toRadians(lon))
+	n := wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+
+	return (n + alt) * cosLat * cosLon,
+		(n + alt) * cosLat * sinLon,
+		(n*(1-wgs84E2) + alt) * sinLat
+}
+
+func fromECEF(x, y, z float64) (float64, float64, float64) {
+	lon := math.Atan2(y, x)
+	p := math.Hypot(x, y)
+
+	lat := math.Atan2(z, p*(1-wgs84E2))
+	var alt float64
+	for i := 0; i < 10; i++ {
+		sinLat := math.Sin(lat)
+		n := wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+		alt = p/math.Cos(lat) - n
+		next := math.Atan2(z, p*(1-wgs84E2*n/(n+alt)))
+		if math.Abs(next-lat) < 1e-13 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+
+	return toDegrees(lat), toDegrees(lon), alt
+}
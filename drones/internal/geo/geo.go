@@ -0,0 +1,222 @@
+package geo
+
+import "math"
+
+const (
+	// Средний радиус Земли для расчетов по большому кругу
+	EarthRadius = 6371008.8
+
+	// Параметры эллипсоида WGS-84; wgs84E2 — квадрат эксцентриситета
+	wgs84A  = 6378137.0
+	wgs84F  = 1 / 298.257223563
+	wgs84B  = wgs84A * (1 - wgs84F)
+	wgs84E2 = wgs84F * (2 - wgs84F)
+)
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+func normalizeBearing(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// Distance возвращает расстояние по большому кругу в метрах (формула гаверсинусов).
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	return angularDistance(lat1, lon1, lat2, lon2) * EarthRadius
+}
+
+func angularDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := toRadians(lat1), toRadians(lat2)
+	dPhi := toRadians(lat2 - lat1)
+	dLambda := toRadians(lon2 - lon1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+
+	return 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// EllipsoidalDistance возвращает расстояние по эллипсоиду WGS-84 в метрах
+// (обратная задача Винсенти). Для почти антиподальных точек, где итерации
+// не сходятся, используется расстояние по большому кругу.
+func EllipsoidalDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	L := toRadians(lon2 - lon1)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(toRadians(lat1)))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(toRadians(lat2)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Sqrt((cosU2*sinLambda)*(cosU2*sinLambda) +
+			(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda))
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		C := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*wgs84F*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < 1e-12 {
+			uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+			B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+			deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+			return wgs84B * A * (sigma - deltaSigma)
+		}
+	}
+
+	return Distance(lat1, lon1, lat2, lon2)
+}
+
+// InitialBearing возвращает начальный азимут от первой точки ко второй в градусах [0, 360).
+func InitialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := toRadians(lat1), toRadians(lat2)
+	dLambda := toRadians(lon2 - lon1)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+
+	return normalizeBearing(toDegrees(math.Atan2(y, x)))
+}
+
+// Destination возвращает точку, находящуюся на заданном расстоянии (м)
+// от исходной по начальному азимуту bearing (градусы).
+func Destination(lat, lon, bearing, distance float64) (float64, float64) {
+	phi1, lambda1 := toRadians(lat), toRadians(lon)
+	theta := toRadians(bearing)
+	delta := distance / EarthRadius
+
+	sinPhi2 := math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta)
+	phi2 := math.Asin(sinPhi2)
+	y := math.Sin(theta) * math.Sin(delta) * math.Cos(phi1)
+	x := math.Cos(delta) - math.Sin(phi1)*sinPhi2
+	lambda2 := lambda1 + math.Atan2(y, x)
+
+	return toDegrees(phi2), math.Mod(toDegrees(lambda2)+540, 360) - 180
+}
+
+// CrossTrackDistance возвращает расстояние (м) от точки до большого круга,
+// проходящего через start и end. Положительное значение — справа от пути.
+func CrossTrackDistance(lat, lon, startLat, startLon, endLat, endLon float64) float64 {
+	delta13 := angularDistance(startLat, startLon, lat, lon)
+	theta13 := toRadians(InitialBearing(startLat, startLon, lat, lon))
+	theta12 := toRadians(InitialBearing(startLat, startLon, endLat, endLon))
+
+	return math.Asin(math.Sin(delta13)*math.Sin(theta13-theta12)) * EarthRadius
+}
+
+// AlongTrackDistance возвращает расстояние (м) от start до проекции точки на
+// путь start→end. Отрицательное значение — проекция лежит позади start.
+func AlongTrackDistance(lat, lon, startLat, startLon, endLat, endLon float64) float64 {
+	delta13 := angularDistance(startLat, startLon, lat, lon)
+	theta13 := toRadians(InitialBearing(startLat, startLon, lat, lon))
+	theta12 := toRadians(InitialBearing(startLat, startLon, endLat, endLon))
+	deltaXt := math.Asin(math.Sin(delta13) * math.Sin(theta13-theta12))
+
+	cosRatio := math.Cos(delta13) / math.Cos(deltaXt)
+	cosRatio = math.Max(-1, math.Min(1, cosRatio))
+
+	distance := math.Acos(cosRatio) * EarthRadius
+	if math.Cos(theta13-theta12) < 0 {
+		return -distance
+	}
+	return distance
+}
+
+// DistanceToSegment возвращает кратчайшее расстояние (м) от точки до отрезка start→end.
+func DistanceToSegment(lat, lon, startLat, startLon, endLat, endLon float64) float64 {
+	length := Distance(startLat, startLon, endLat, endLon)
+	if length == 0 {
+		return Distance(lat, lon, startLat, startLon)
+	}
+
+	along := AlongTrackDistance(lat, lon, startLat, startLon, endLat, endLon)
+	if along <= 0 {
+		return Distance(lat, lon, startLat, startLon)
+	}
+	if along >= length {
+		return Distance(lat, lon, endLat, endLon)
+	}
+
+	return math.Abs(CrossTrackDistance(lat, lon, startLat, startLon, endLat, endLon))
+}
+
+// IntermediatePoint возвращает точку на большом круге start→end, делящую путь
+// в доле fraction: 0 — start, 1 — end.
+func IntermediatePoint(startLat, startLon, endLat, endLon, fraction float64) (float64, float64) {
+	delta := angularDistance(startLat, startLon, endLat, endLon)
+	if delta == 0 {
+		return startLat, startLon
+	}
+
+	phi1, lambda1 := toRadians(startLat), toRadians(startLon)
+	phi2, lambda2 := toRadians(endLat), toRadians(endLon)
+
+	a := math.Sin((1-fraction)*delta) / math.Sin(delta)
+	b := math.Sin(fraction*delta) / math.Sin(delta)
+
+	x := a*math.Cos(phi1)*math.Cos(lambda1) + b*math.Cos(phi2)*math.Cos(lambda2)
+	y := a*math.Cos(phi1)*math.Sin(lambda1) + b*math.Cos(phi2)*math.Sin(lambda2)
+	z := a*math.Sin(phi1) + b*math.Sin(phi2)
+
+	return toDegrees(math.Atan2(z, math.Hypot(x, y))), toDegrees(math.Atan2(y, x))
+}
+
+// EllipsoidalDistanceToSegment возвращает кратчайшее расстояние (м) по
+// эллипсоиду WGS-84 от точки до отрезка start→end. Ближайшая точка отрезка
+// ищется золотым сечением, а концы сравниваются через EllipsoidalDistance,
+// поэтому результат никогда не больше расстояния до любого из концов.
+func EllipsoidalDistanceToSegment(lat, lon, startLat, startLon, endLat, endLon float64) float64 {
+	distanceAt := func(fraction float64) float64 {
+		pointLat, pointLon := IntermediatePoint(startLat, startLon, endLat, endLon, fraction)
+		return EllipsoidalDistance(lat, lon, pointLat, pointLon)
+	}
+
+	best := math.Min(
+		EllipsoidalDistance(lat, lon, startLat, startLon),
+		EllipsoidalDistance(lat, lon, endLat, endLon),
+	)
+
+	// На отрезке короче четверти окружности расстояние до точки унимодально
+	ratio := (math.Sqrt(5) - 1) / 2
+	low, high := 0.0, 1.0
+	left, right := high-ratio*(high-low), low+ratio*(high-low)
+	leftDistance, rightDistance := distanceAt(left), distanceAt(right)
+	for high-low > 1e-9 {
+		if leftDistance < rightDistance {
+			high, right, rightDistance = right, left, leftDistance
+			left = high - ratio*(high-low)
+			leftDistance = distanceAt(left)
+		} else {
+			low, left, leftDistance = left, right, rightDistance
+			right = low + ratio*(high-low)
+			rightDistance = distanceAt(right)
+		}
+	}
+
+	return math.Min(best, distanceAt((low+high)/2))
+}
@@ -0,0 +1,240 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Эталонные значения для сферических формул взяты из примеров Movable Type
+// (R = 6371 км), для эллипсоида — из статьи Винсенти (Flinders Peak → Buninyong).
+
+func dms(deg, min, sec float64) float64 {
+	sign := 1.0
+	if deg < 0 {
+		sign, deg = -1, -deg
+	}
+	return sign * (deg + min/60 + sec/3600)
+}
+
+func assertNear(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %.6f, want %.6f ± %g", name, got, want, tolerance)
+	}
+}
+
+// assertBearing сравнивает азимуты по кратчайшей дуге: 359.9999° и 0° совпадают.
+func assertBearing(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	diff := math.Mod(got-want+540, 360) - 180
+	if math.Abs(diff) > tolerance {
+		t.Errorf("%s = %.6f, want %.6f ± %g", name, got, want, tolerance)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want, tolerance        float64
+	}{
+		{"Land's End to John o' Groats", dms(50, 3, 59), dms(-5, 42, 53), dms(58, 38, 38), dms(-3, 4, 12), 968_900, 200},
+		{"one degree of latitude", 0, 0, 1, 0, EarthRadius * math.Pi / 180, 1e-6},
+		{"quarter meridian", 0, 0, 90, 0, EarthRadius * math.Pi / 2, 1e-6},
+		{"same point", 51.15545, 71.41216, 51.15545, 71.41216, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNear(t, "Distance", Distance(tt.lat1, tt.lon1, tt.lat2, tt.lon2), tt.want, tt.tolerance)
+			assertNear(t, "Distance reversed", Distance(tt.lat2, tt.lon2, tt.lat1, tt.lon1), tt.want, tt.tolerance)
+		})
+	}
+}
+
+func TestEllipsoidalDistance(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want, tolerance        float64
+	}{
+		{
+			"Flinders Peak to Buninyong",
+			dms(-37, 57, 3.72030), dms(144, 25, 29.52440),
+			dms(-37, 39, 10.15610), dms(143, 55, 35.38390),
+			54_972.271, 0.001,
+		},
+		{"equator, one degree of longitude", 0, 0, 0, 1, 111_319.491, 0.001},
+		{"quarter meridian", 0, 0, 90, 0, 10_001_965.729, 0.001},
+		{"same point", 51.15545, 71.41216, 51.15545, 71.41216, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNear(t, "EllipsoidalDistance", EllipsoidalDistance(tt.lat1, tt.lon1, tt.lat2, tt.lon2), tt.want, tt.tolerance)
+		})
+	}
+}
+
+func TestInitialBearing(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{"Land's End to John o' Groats", dms(50, 3, 59), dms(-5, 42, 53), dms(58, 38, 38), dms(-3, 4, 12), dms(9, 7, 11)},
+		{"due north", 51, 71, 52, 71, 0},
+		{"due south", 51, 71, 50, 71, 180},
+		{"due east on equator", 0, 10, 0, 11, 90},
+		{"due west on equator", 0, 10, 0, 9, 270},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertBearing(t, "InitialBearing", InitialBearing(tt.lat1, tt.lon1, tt.lat2, tt.lon2), tt.want, 1e-3)
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	lat, lon := Destination(53.3206, -1.7297, 96.0217, 124_800)
+	assertNear(t, "latitude", lat, dms(53, 11, 18), 1e-3)
+	assertNear(t, "longitude", lon, dms(0, 8, 0), 1e-3)
+
+	lat, lon = Destination(0, 179.5, 90, Distance(0, 0, 0, 1))
+	assertNear(t, "latitude across antimeridian", lat, 0, 1e-9)
+	assertNear(t, "longitude across antimeridian", lon, -179.5, 1e-9)
+}
+
+func TestDestinationInvertsDistanceAndBearing(t *testing.T) {
+	startLat, startLon := 51.15545, 71.41216
+
+	for _, bearing := range []float64{0, 37.5, 90, 145, 180, 233.3, 270, 315} {
+		for _, distance := range []float64{1, 150, 5_000, 250_000} {
+			lat, lon := Destination(startLat, startLon, bearing, distance)
+
+			assertNear(t, "Distance", Distance(startLat, startLon, lat, lon), distance, distance*1e-9+1e-6)
+			assertBearing(t, "InitialBearing", InitialBearing(startLat, startLon, lat, lon), bearing, 1e-6)
+		}
+	}
+}
+
+func TestCrossAndAlongTrackDistance(t *testing.T) {
+	lat, lon := 53.2611, -0.7972
+	startLat, startLon := 53.3206, -1.7297
+	endLat, endLon := 53.1887, 0.1334
+
+	assertNear(t, "CrossTrackDistance", CrossTrackDistance(lat, lon, startLat, startLon, endLat, endLon), -307.5, 0.5)
+	assertNear(t, "AlongTrackDistance", AlongTrackDistance(lat, lon, startLat, startLon, endLat, endLon), 62_331, 10)
+}
+
+func TestTrackDistancesOnMeridian(t *testing.T) {
+	// Путь вдоль меридиана на север: точка к востоку лежит справа от пути
+	startLat, startLon := 51.0, 71.0
+	endLat, endLon := 52.0, 71.0
+	pointLat, pointLon := Destination(51.5, 71.0, 90, 300)
+
+	assertNear(t, "CrossTrackDistance", CrossTrackDistance(pointLat, pointLon, startLat, startLon, endLat, endLon), 300, 0.01)
+	assertNear(t, "AlongTrackDistance", AlongTrackDistance(pointLat, pointLon, startLat, startLon, endLat, endLon), Distance(51, 71, 51.5, 71), 0.01)
+
+	behindLat, behindLon := Destination(startLat, startLon, 180, 1_000)
+	assertNear(t, "AlongTrackDistance behind start", AlongTrackDistance(behindLat, behindLon, startLat, startLon, endLat, endLon), -1_000, 0.01)
+	assertNear(t, "DistanceToSegment behind start", DistanceToSegment(behindLat, behindLon, startLat, startLon, endLat, endLon), 1_000, 0.01)
+	assertNear(t, "DistanceToSegment abeam", DistanceToSegment(pointLat, pointLon, startLat, startLon, endLat, endLon), 300, 0.01)
+}
+
+func TestIntermediatePoint(t *testing.T) {
+	lat, lon := IntermediatePoint(51, 71, 52, 71, 0.5)
+	assertNear(t, "midpoint latitude on meridian", lat, 51.5, 1e-9)
+	assertNear(t, "midpoint longitude on meridian", lon, 71, 1e-9)
+
+	startLat, startLon, endLat, endLon := 53.3206, -1.7297, 53.1887, 0.1334
+	lat, lon = IntermediatePoint(startLat, startLon, endLat, endLon, 0.25)
+	assertNear(t, "distance to quarter point", Distance(startLat, startLon, lat, lon),
+		Distance(startLat, startLon, endLat, endLon)/4, 1e-3)
+}
+
+func TestEllipsoidalDistanceToSegment(t *testing.T) {
+	startLat, startLon := 51.0, 71.0
+	endLat, endLon := 52.0, 71.0
+
+	abeamLat, abeamLon := Destination(51.5, 71, 90, 300)
+	behindLat, behindLon := Destination(startLat, startLon, 200, 1_000)
+	beyondLat, beyondLon := Destination(endLat, endLon, 10, 2_000)
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+	}{
+		{"abeam", abeamLat, abeamLon, EllipsoidalDistance(abeamLat, abeamLon, 51.5, 71)},
+		{"behind start", behindLat, behindLon, EllipsoidalDistance(behindLat, behindLon, startLat, startLon)},
+		{"beyond end", beyondLat, beyondLon, EllipsoidalDistance(beyondLat, beyondLon, endLat, endLon)},
+		{"on segment", 51.25, 71, 0},
+	}
+
+	for _, tt := range tests {
+		got := EllipsoidalDistanceToSegment(tt.lat, tt.lon, startLat, startLon, endLat, endLon)
+		assertNear(t, tt.name, got, tt.want, 1e-3)
+
+		// Отрезок не может быть дальше своих концов: иначе точка маршрута и
+		// прилегающий участок по-разному решали бы, задета ли зона
+		for _, end := range [][2]float64{{startLat, startLon}, {endLat, endLon}} {
+			if endpoint := EllipsoidalDistance(tt.lat, tt.lon, end[0], end[1]); got > endpoint {
+				t.Errorf("%s: segment distance %.6f exceeds endpoint distance %.6f", tt.name, got, endpoint)
+			}
+		}
+	}
+}
+
+func TestECEFReference(t *testing.T) {
+	x, y, z := toECEF(0, 0, 0)
+	assertNear(t, "x at equator", x, wgs84A, 1e-6)
+	assertNear(t, "y at equator", y, 0, 1e-6)
+	assertNear(t, "z at equator", z, 0, 1e-6)
+
+	x, y, z = toECEF(90, 0, 0)
+	assertNear(t, "x at pole", x, 0, 1e-6)
+	assertNear(t, "y at pole", y, 0, 1e-6)
+	assertNear(t, "z at pole", z, wgs84B, 1e-6)
+}
+
+func TestENURoundTrip(t *testing.T) {
+	enu := NewENU(51.15545, 71.41216, 350)
+
+	points := []struct{ lat, lon, alt float64 }{
+		{51.15545, 71.41216, 350},
+		{51.16, 71.42, 400},
+		{51.10, 71.30, 0},
+		{51.40, 71.90, 1_200},
+	}
+
+	for _, p := range points {
+		east, north, up := enu.Forward(p.lat, p.lon, p.alt)
+		lat, lon, alt := enu.Inverse(east, north, up)
+
+		assertNear(t, "latitude", lat, p.lat, 1e-9)
+		assertNear(t, "longitude", lon, p.lon, 1e-9)
+		assertNear(t, "altitude", alt, p.alt, 1e-4)
+	}
+}
+
+func TestENUAxes(t *testing.T) {
+	originLat, originLon := 51.15545, 71.41216
+	enu := NewENU(originLat, originLon, 0)
+
+	east, north, up := enu.Forward(originLat, originLon, 100)
+	assertNear(t, "east of zenith", east, 0, 1e-6)
+	assertNear(t, "north of zenith", north, 0, 1e-6)
+	assertNear(t, "up of zenith", up, 100, 1e-6)
+
+	lat, lon := Destination(originLat, originLon, 0, 1_000)
+	east, north, _ = enu.Forward(lat, lon, 0)
+	assertNear(t, "east of point due north", east, 0, 1e-6)
+	assertNear(t, "north of point due north", north, 1_000, 5)
+
+	lat, lon = Destination(originLat, originLon, 90, 1_000)
+	east, north, _ = enu.Forward(lat, lon, 0)
+	assertNear(t, "east of point due east", east, 1_000, 5)
+	assertNear(t, "north of point due east", north, 0, 0.5)
+}
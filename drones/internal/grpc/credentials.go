@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/qwaq-dev/drones/internal/config"
+)
+
+func dialOptions(cfg *config.Config) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if cfg.GRPCAuthToken == "" && !cfg.GRPCInsecureDev {
+		return nil, errors.New("GRPC_AUTH_TOKEN is required unless GRPC_INSECURE_DEV is set")
+	}
+
+	if cfg.GRPCTLS {
+		creds, err := transportCredentials(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else if !cfg.GRPCInsecureDev {
+		return nil, errors.New("GRPC_TLS or GRPC_CA_FILE is required unless GRPC_INSECURE_DEV is set")
+	} else {
+		log.Println("WARNING: gRPC connection to backend is not encrypted")
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if cfg.GRPCAuthToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{
+			token:      cfg.GRPCAuthToken,
+			requireTLS: cfg.GRPCTLS,
+		}))
+	}
+
+	return opts, nil
+}
+
+// transportCredentials проверяет сервер по GRPC_CA_FILE (или системным CA)
+// и, если заданы GRPC_CERT_FILE и GRPC_KEY_FILE, предъявляет клиентский
+// сертификат для mTLS.
+func transportCredentials(cfg *config.Config) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		ServerName: cfg.GRPCServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.GRPCCAFile != "" {
+		pem, err := os.ReadFile(cfg.GRPCCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.GRPCCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.GRPCCertFile != "" || cfg.GRPCKeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.GRPCCertFile, cfg.GRPCKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// tokenCredentials добавляет токен сервиса в каждый вызов.
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
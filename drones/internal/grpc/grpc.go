@@ -4,40 +4,64 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/qwaq-dev/drones/internal/config"
 	"github.com/qwaq-dev/drones/internal/structures"
 	pb "github.com/qwaq-dev/drones/proto"
 )
 
 type NotificationClient struct {
-	client pb.FlightNotificationServiceClient
-	conn   *grpc.ClientConn
+	client    pb.FlightNotificationServiceClient
+	conn      *grpc.ClientConn
+	telemetry *telemetryStream
+	cancel    context.CancelFunc
 }
 
-func NewNotificationClient(serverAddress string) (*NotificationClient, error) {
-	conn, err := grpc.NewClient(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewNotificationClient(cfg *config.Config) (*NotificationClient, error) {
+	opts, err := dialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(cfg.GRPCServerAddress, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
 	}
 
 	client := pb.NewFlightNotificationServiceClient(conn)
+	telemetry := newTelemetryStream(client, cfg.TelemetryBatchSize, cfg.TelemetryFlushInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go telemetry.run(ctx)
 
 	return &NotificationClient{
-		client: client,
-		conn:   conn,
+		client:    client,
+		conn:      conn,
+		telemetry: telemetry,
+		cancel:    cancel,
 	}, nil
 }
 
 func (nc *NotificationClient) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := nc.telemetry.flush(ctx); err != nil {
+		log.Printf("Telemetry not fully delivered before shutdown: %v", err)
+	}
+
+	nc.cancel()
 	return nc.conn.Close()
 }
 
-func (nc *NotificationClient) NotifyStatusUpdate(ctx context.Context, applicationId int, status structures.Status, message, rejectionReason string) error {
+// StatusUpdateMessage готовит уведомление о смене статуса для записи в outbox.
+func StatusUpdateMessage(applicationId int, status structures.Status, message, rejectionReason string) (structures.OutboxMessage, error) {
 	req := &pb.StatusUpdateRequest{
+		EventId:         newEventId(),
 		ApplicationId:   int32(applicationId),
 		Status:          string(status),
 		Message:         message,
@@ -45,20 +69,11 @@ func (nc *NotificationClient) NotifyStatusUpdate(ctx context.Context, applicatio
 		Timestamp:       timestamppb.Now(),
 	}
 
-	resp, err := nc.client.NotifyStatusUpdate(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to notify status update: %w", err)
-	}
-
-	if !resp.Success {
-		return fmt.Errorf("status update notification failed: %s", resp.ErrorMessage)
-	}
-
-	log.Printf("Status update notification sent for application %d: %s", applicationId, status)
-	return nil
+	return newOutboxMessage(applicationId, structures.OutboxStatusUpdate, req.EventId, req)
 }
 
-func (nc *NotificationClient) NotifyFlightStarted(ctx context.Context, flight *structures.ActiveFlight) error {
+// FlightStartedMessage готовит уведомление о начале полета для записи в outbox.
+func FlightStartedMessage(flight *structures.ActiveFlight) (structures.OutboxMessage, error) {
 	route := make([]*pb.RoutePoint, len(flight.Route))
 	for i, point := range flight.Route {
 		route[i] = &pb.RoutePoint{
@@ -68,6 +83,10 @@ func (nc *NotificationClient) NotifyFlightStarted(ctx context.Context, flight *s
 			Altitude:      point.Altitude,
 			PointOrder:    int32(point.PointOrder),
 			ApplicationId: int32(point.ApplicationId),
+			Actions:       make([]*pb.WaypointAction, len(point.Actions)),
+		}
+		for j, action := range point.Actions {
+			route[i].Actions[j] = waypointActionToProto(action)
 		}
 	}
 
@@ -84,6 +103,7 @@ func (nc *NotificationClient) NotifyFlightStarted(ctx context.Context, flight *s
 	}
 
 	req := &pb.FlightStartedRequest{
+		EventId:          newEventId(),
 		ApplicationId:    int32(flight.ApplicationId),
 		DroneId:          int32(flight.DroneId),
 		PilotId:          int32(flight.PilotId),
@@ -93,45 +113,36 @@ func (nc *NotificationClient) NotifyFlightStarted(ctx context.Context, flight *s
 		EstimatedEndTime: timestamppb.New(flight.EstimatedEndTime),
 	}
 
-	resp, err := nc.client.NotifyFlightStarted(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to notify flight started: %w", err)
-	}
-
-	if !resp.Success {
-		return fmt.Errorf("flight started notification failed: %s", resp.ErrorMessage)
-	}
-
-	log.Printf("Flight started notification sent for application %d", flight.ApplicationId)
-	return nil
+	return newOutboxMessage(flight.ApplicationId, structures.OutboxFlightStarted, req.EventId, req)
 }
 
-func (nc *NotificationClient) UpdateDronePosition(ctx context.Context, position structures.DronePosition) error {
+// UpdateDronePosition ставит позицию в поток телеметрии и не ждет сети.
+func (nc *NotificationClient) UpdateDronePosition(position structures.DronePosition) {
 	req := &pb.DronePositionRequest{
-		ApplicationId: int32(position.ApplicationId),
-		DroneId:       int32(position.DroneId),
-		Latitude:      position.Latitude,
-		Longitude:     position.Longitude,
-		Altitude:      position.Altitude,
-		Speed:         position.Speed,
-		Heading:       position.Heading,
-		RouteProgress: position.RouteProgress,
-		Timestamp:     timestamppb.New(position.Timestamp),
-	}
-
-	resp, err := nc.client.UpdateDronePosition(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to update drone position: %w", err)
+		EventId:           newEventId(),
+		ApplicationId:     int32(position.ApplicationId),
+		DroneId:           int32(position.DroneId),
+		Latitude:          position.Latitude,
+		Longitude:         position.Longitude,
+		Altitude:          position.Altitude,
+		Speed:             position.Speed,
+		Heading:           position.Heading,
+		RouteProgress:     position.RouteProgress,
+		Timestamp:         timestamppb.New(position.Timestamp),
+		EstimatedEndTime:  timestamppb.New(position.EstimatedEndTime),
+		DistanceRemaining: position.DistanceRemaining,
 	}
 
-	if !resp.Success {
-		return fmt.Errorf("drone position update failed: %s", resp.ErrorMessage)
-	}
+	nc.telemetry.enqueue(req)
+}
 
-	return nil
+// FlushTelemetry ждет подтверждения всех поставленных в поток позиций.
+func (nc *NotificationClient) FlushTelemetry(ctx context.Context) error {
+	return nc.telemetry.flush(ctx)
 }
 
-func (nc *NotificationClient) NotifyFlightCompleted(ctx context.Context, flight *structures.ActiveFlight, completionStatus string) error {
+// FlightCompletedMessage готовит уведомление о завершении полета для записи в outbox.
+func FlightCompletedMessage(flight *structures.ActiveFlight, completionStatus string) (structures.OutboxMessage, error) {
 	finalPos := &pb.DronePosition{
 		ApplicationId: int32(flight.CurrentPosition.ApplicationId),
 		DroneId:       int32(flight.CurrentPosition.DroneId),
@@ -145,6 +156,7 @@ func (nc *NotificationClient) NotifyFlightCompleted(ctx context.Context, flight
 	}
 
 	req := &pb.FlightCompletedRequest{
+		EventId:          newEventId(),
 		ApplicationId:    int32(flight.ApplicationId),
 		DroneId:          int32(flight.DroneId),
 		FinalPosition:    finalPos,
@@ -152,26 +164,17 @@ func (nc *NotificationClient) NotifyFlightCompleted(ctx context.Context, flight
 		CompletionStatus: completionStatus,
 	}
 
-	resp, err := nc.client.NotifyFlightCompleted(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to notify flight completed: %w", err)
-	}
-
-	if !resp.Success {
-		return fmt.Errorf("flight completed notification failed: %s", resp.ErrorMessage)
-	}
-
-	log.Printf("Flight completed notification sent for application %d", flight.ApplicationId)
-	return nil
+	return newOutboxMessage(flight.ApplicationId, structures.OutboxFlightCompleted, req.EventId, req)
 }
 
 func (nc *NotificationClient) NotifyRestrictedZoneProximity(ctx context.Context, applicationId, droneId int, zone structures.RestrictedZone, alertLevel string, distance float64, position structures.DronePosition) error {
 	req := &pb.RestrictedZoneAlertRequest{
+		EventId:       newEventId(),
 		ApplicationId: int32(applicationId),
 		DroneId:       int32(droneId),
 		ZoneName:      zone.Name,
 		ZoneLatitude:  zone.Latitude,
-		ZoneLongitude: zone.Longtitude,
+		ZoneLongitude: zone.Longitude,
 		ZoneRadius:    int32(zone.Radius),
 		AlertLevel:    alertLevel,
 		Distance:      distance,
@@ -217,6 +220,7 @@ func (nc *NotificationClient) NotifyFlightPaused(ctx context.Context, flight *st
 	}
 
 	req := &pb.FlightPausedRequest{
+		EventId:       newEventId(),
 		ApplicationId: int32(flight.ApplicationId),
 		DroneId:       int32(flight.DroneId),
 		PausePosition: pausePos,
@@ -237,6 +241,54 @@ func (nc *NotificationClient) NotifyFlightPaused(ctx context.Context, flight *st
 	return nil
 }
 
+// NotifyWaypointAction сообщает о начале или завершении действия в точке
+// маршрута. Мгновенные действия присылают только phase=completed.
+func (nc *NotificationClient) NotifyWaypointAction(ctx context.Context, flight *structures.ActiveFlight, action structures.WaypointAction, phase string) error {
+	position := &pb.DronePosition{
+		ApplicationId: int32(flight.CurrentPosition.ApplicationId),
+		DroneId:       int32(flight.CurrentPosition.DroneId),
+		Latitude:      flight.CurrentPosition.Latitude,
+		Longitude:     flight.CurrentPosition.Longitude,
+		Altitude:      flight.CurrentPosition.Altitude,
+		Speed:         flight.CurrentPosition.Speed,
+		Heading:       flight.CurrentPosition.Heading,
+		RouteProgress: flight.CurrentPosition.RouteProgress,
+		Timestamp:     timestamppb.New(flight.CurrentPosition.Timestamp),
+	}
+
+	req := &pb.WaypointActionRequest{
+		EventId:       newEventId(),
+		ApplicationId: int32(flight.ApplicationId),
+		DroneId:       int32(flight.DroneId),
+		Waypoint:      int32(flight.CurrentWaypoint),
+		Action:        waypointActionToProto(action),
+		Phase:         phase,
+		DronePosition: position,
+		Timestamp:     timestamppb.Now(),
+	}
+
+	resp, err := nc.client.NotifyWaypointAction(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to notify waypoint action: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("waypoint action notification failed: %s", resp.ErrorMessage)
+	}
+
+	return nil
+}
+
+func waypointActionToProto(action structures.WaypointAction) *pb.WaypointAction {
+	return &pb.WaypointAction{
+		Type:    string(action.Type),
+		Seconds: action.Seconds,
+		Radius:  action.Radius,
+		Turns:   action.Turns,
+		Speed:   action.Speed,
+	}
+}
+
 func (nc *NotificationClient) NotifyFlightResumed(ctx context.Context, flight *structures.ActiveFlight, reason string) error {
 	resumePos := &pb.DronePosition{
 		ApplicationId: int32(flight.CurrentPosition.ApplicationId),
@@ -251,6 +303,7 @@ func (nc *NotificationClient) NotifyFlightResumed(ctx context.Context, flight *s
 	}
 
 	req := &pb.FlightResumedRequest{
+		EventId:        newEventId(),
 		ApplicationId:  int32(flight.ApplicationId),
 		DroneId:        int32(flight.DroneId),
 		ResumePosition: resumePos,
@@ -270,3 +323,91 @@ func (nc *NotificationClient) NotifyFlightResumed(ctx context.Context, flight *s
 	log.Printf("Flight resumed notification sent for application %d", flight.ApplicationId)
 	return nil
 }
+
+func (nc *NotificationClient) NotifyTrafficConflict(ctx context.Context, flight, intruder *structures.ActiveFlight, alertLevel string, horizontal, vertical float64, resolution structures.ConflictResolution) error {
+	req := &pb.TrafficConflictRequest{
+		EventId:               newEventId(),
+		ApplicationId:         int32(flight.ApplicationId),
+		DroneId:               int32(flight.DroneId),
+		IntruderApplicationId: int32(intruder.ApplicationId),
+		IntruderDroneId:       int32(intruder.DroneId),
+		AlertLevel:            alertLevel,
+		HorizontalSeparation:  horizontal,
+		VerticalSeparation:    vertical,
+		Resolution:            string(resolution),
+		DronePosition: &pb.DronePosition{
+			ApplicationId: int32(flight.CurrentPosition.ApplicationId),
+			DroneId:       int32(flight.CurrentPosition.DroneId),
+			Latitude:      flight.CurrentPosition.Latitude,
+			Longitude:     flight.CurrentPosition.Longitude,
+			Altitude:      flight.CurrentPosition.Altitude,
+			Speed:         flight.CurrentPosition.Speed,
+			Heading:       flight.CurrentPosition.Heading,
+			RouteProgress: flight.CurrentPosition.RouteProgress,
+			Timestamp:     timestamppb.New(flight.CurrentPosition.Timestamp),
+		},
+		IntruderPosition: &pb.DronePosition{
+			ApplicationId: int32(intruder.CurrentPosition.ApplicationId),
+			DroneId:       int32(intruder.CurrentPosition.DroneId),
+			Latitude:      intruder.CurrentPosition.Latitude,
+			Longitude:     intruder.CurrentPosition.Longitude,
+			Altitude:      intruder.CurrentPosition.Altitude,
+			Speed:         intruder.CurrentPosition.Speed,
+			Heading:       intruder.CurrentPosition.Heading,
+			RouteProgress: intruder.CurrentPosition.RouteProgress,
+			Timestamp:     timestamppb.New(intruder.CurrentPosition.Timestamp),
+		},
+		Timestamp: timestamppb.Now(),
+	}
+
+	resp, err := nc.client.NotifyTrafficConflict(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to notify traffic conflict: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("traffic conflict notification failed: %s", resp.ErrorMessage)
+	}
+
+	log.Printf("Traffic conflict alert sent: application %d vs %d, %s level, %.1fm horizontal, %.1fm vertical",
+		flight.ApplicationId, intruder.ApplicationId, alertLevel, horizontal, vertical)
+	return nil
+}
+
+func (nc *NotificationClient) NotifyRouteDeviation(ctx context.Context, flight *structures.ActiveFlight, deviationType structures.DeviationType, alertLevel string, report structures.ConformanceReport) error {
+	req := &pb.RouteDeviationRequest{
+		EventId:              newEventId(),
+		ApplicationId:        int32(flight.ApplicationId),
+		DroneId:              int32(flight.DroneId),
+		DeviationType:        string(deviationType),
+		AlertLevel:           alertLevel,
+		LateralDeviation:     report.LateralDeviation,
+		VerticalDeviation:    report.VerticalDeviation,
+		TimeDeviationSeconds: report.TimeDeviationSeconds,
+		Leg:                  int32(report.Leg),
+		DronePosition: &pb.DronePosition{
+			ApplicationId: int32(flight.CurrentPosition.ApplicationId),
+			DroneId:       int32(flight.CurrentPosition.DroneId),
+			Latitude:      flight.CurrentPosition.Latitude,
+			Longitude:     flight.CurrentPosition.Longitude,
+			Altitude:      flight.CurrentPosition.Altitude,
+			Speed:         flight.CurrentPosition.Speed,
+			Heading:       flight.CurrentPosition.Heading,
+			RouteProgress: flight.CurrentPosition.RouteProgress,
+			Timestamp:     timestamppb.New(flight.CurrentPosition.Timestamp),
+		},
+		Timestamp: timestamppb.Now(),
+	}
+
+	resp, err := nc.client.NotifyRouteDeviation(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to notify route deviation: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("route deviation notification failed: %s", resp.ErrorMessage)
+	}
+
+	log.Printf("Route deviation alert sent: application %d, %s %s", flight.ApplicationId, deviationType, alertLevel)
+	return nil
+}
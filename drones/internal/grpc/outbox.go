@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/qwaq-dev/drones/internal/structures"
+	pb "github.com/qwaq-dev/drones/proto"
+)
+
+// ErrMalformedMessage означает, что сообщение невозможно отправить ни при
+// какой попытке, и повторять его бессмысленно.
+var ErrMalformedMessage = errors.New("malformed outbox message")
+
+type notificationResponse interface {
+	GetSuccess() bool
+	GetErrorMessage() string
+}
+
+// newEventId выдает идентификатор события для event_id запроса. Повторная
+// отправка того же события должна идти с тем же идентификатором.
+func newEventId() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id)
+}
+
+// newOutboxMessage сохраняет event_id запроса ключом идемпотентности: он
+// уходит на бэкенд при каждой попытке доставки.
+func newOutboxMessage(applicationId int, eventType, eventId string, req proto.Message) (structures.OutboxMessage, error) {
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		return structures.OutboxMessage{}, fmt.Errorf("failed to marshal %s notification: %w", eventType, err)
+	}
+
+	return structures.OutboxMessage{
+		IdempotencyKey: eventId,
+		ApplicationId:  applicationId,
+		EventType:      eventType,
+		Payload:        payload,
+	}, nil
+}
+
+// Deliver отправляет сохраненное в outbox уведомление. Ответ с Success=false
+// считается неудачной доставкой, как и ошибка транспорта.
+func (nc *NotificationClient) Deliver(ctx context.Context, msg structures.OutboxMessage) error {
+	var resp notificationResponse
+	var err error
+
+	switch msg.EventType {
+	case structures.OutboxStatusUpdate:
+		req := &pb.StatusUpdateRequest{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+		}
+		resp, err = nc.client.NotifyStatusUpdate(ctx, req)
+	case structures.OutboxFlightStarted:
+		req := &pb.FlightStartedRequest{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+		}
+		resp, err = nc.client.NotifyFlightStarted(ctx, req)
+	case structures.OutboxFlightCompleted:
+		req := &pb.FlightCompletedRequest{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+		}
+		resp, err = nc.client.NotifyFlightCompleted(ctx, req)
+	default:
+		return fmt.Errorf("%w: unknown event type %q", ErrMalformedMessage, msg.EventType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to deliver %s notification: %w", msg.EventType, err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("%s notification rejected: %s", msg.EventType, resp.GetErrorMessage())
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/qwaq-dev/drones/proto"
+)
+
+const (
+	// initialTelemetryWindow действует до первого подтверждения, в котором
+	// сервер сообщает свое окно.
+	initialTelemetryWindow = 4
+	telemetryMinBackoff    = time.Second
+	telemetryMaxBackoff    = 30 * time.Second
+)
+
+// telemetryStream отправляет позиции всех полетов по одному долгоживущему потоку.
+// Пока окно неподтвержденных батчей заполнено, позиции копятся в pending, где
+// для каждой заявки хранится только последняя: устаревшие точки не нужны.
+type telemetryStream struct {
+	client        pb.FlightNotificationServiceClient
+	batchSize     int
+	flushInterval time.Duration
+
+	mutex    sync.Mutex
+	pending  map[int32]*pb.DronePositionRequest
+	order    []int32
+	inFlight map[int64][]*pb.DronePositionRequest
+	window   int
+	sequence int64
+	acked    chan struct{}
+
+	kick chan struct{}
+}
+
+func newTelemetryStream(client pb.FlightNotificationServiceClient, batchSize int, flushInterval time.Duration) *telemetryStream {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if flushInterval <= 0 {
+		flushInterval = 250 * time.Millisecond
+	}
+
+	return &telemetryStream{
+		client:        client,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		pending:       make(map[int32]*pb.DronePositionRequest),
+		inFlight:      make(map[int64][]*pb.DronePositionRequest),
+		window:        initialTelemetryWindow,
+		acked:         make(chan struct{}),
+		kick:          make(chan struct{}, 1),
+	}
+}
+
+func (t *telemetryStream) enqueue(position *pb.DronePositionRequest) {
+	t.mutex.Lock()
+	if _, ok := t.pending[position.ApplicationId]; !ok {
+		t.order = append(t.order, position.ApplicationId)
+	}
+	t.pending[position.ApplicationId] = position
+	full := len(t.pending) >= t.batchSize
+	t.mutex.Unlock()
+
+	if full {
+		t.wake()
+	}
+}
+
+func (t *telemetryStream) wake() {
+	select {
+	case t.kick <- struct{}{}:
+	default:
+	}
+}
+
+// flush ждет, пока все накопленные позиции будут отправлены и подтверждены.
+func (t *telemetryStream) flush(ctx context.Context) error {
+	for {
+		t.mutex.Lock()
+		idle := len(t.pending) == 0 && len(t.inFlight) == 0
+		acked := t.acked
+		t.mutex.Unlock()
+
+		if idle {
+			return nil
+		}
+
+		t.wake()
+
+		select {
+		case <-acked:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (t *telemetryStream) run(ctx context.Context) {
+	backoff := telemetryMinBackoff
+
+	for {
+		opened, err := t.session(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if opened {
+			backoff = telemetryMinBackoff
+		}
+
+		t.requeueInFlight()
+		log.Printf("Telemetry stream interrupted: %v; reconnecting in %s", err, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if backoff > telemetryMaxBackoff {
+			backoff = telemetryMaxBackoff
+		}
+	}
+}
+
+func (t *telemetryStream) session(ctx context.Context) (bool, error) {
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := t.client.StreamTelemetry(sessionCtx)
+	if err != nil {
+		return false, err
+	}
+
+	log.Println("Telemetry stream opened")
+
+	receiveErr := make(chan error, 1)
+	go func() {
+		receiveErr <- t.receiveAcks(stream)
+	}()
+
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.kick:
+		case err := <-receiveErr:
+			return true, err
+		case <-ctx.Done():
+			stream.CloseSend()
+			return true, ctx.Err()
+		}
+
+		if err := t.sendPending(stream); err != nil {
+			return true, err
+		}
+	}
+}
+
+// sendPending отправляет накопленное, пока не кончится окно. Батч собирается
+// под мьютексом, а Send вызывается без него, чтобы процессор не ждал сеть.
+func (t *telemetryStream) sendPending(stream pb.FlightNotificationService_StreamTelemetryClient) error {
+	for {
+		t.mutex.Lock()
+		if len(t.pending) == 0 || len(t.inFlight) >= t.window {
+			t.mutex.Unlock()
+			return nil
+		}
+
+		size := t.batchSize
+		if size > len(t.order) {
+			size = len(t.order)
+		}
+
+		positions := make([]*pb.DronePositionRequest, 0, size)
+		for _, applicationId := range t.order[:size] {
+			positions = append(positions, t.pending[applicationId])
+			delete(t.pending, applicationId)
+		}
+		t.order = append(t.order[:0], t.order[size:]...)
+
+		t.sequence++
+		sequence := t.sequence
+		t.inFlight[sequence] = positions
+		t.mutex.Unlock()
+
+		err := stream.Send(&pb.TelemetryBatch{
+			Sequence:  sequence,
+			Positions: positions,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (t *telemetryStream) receiveAcks(stream pb.FlightNotificationService_StreamTelemetryClient) error {
+	for {
+		ack, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if !ack.Success {
+			log.Printf("Telemetry batch %d partially rejected: %s (%d accepted)", ack.Sequence, ack.ErrorMessage, ack.Accepted)
+		}
+
+		t.mutex.Lock()
+		delete(t.inFlight, ack.Sequence)
+		if ack.Window > 0 {
+			t.window = int(ack.Window)
+		}
+		close(t.acked)
+		t.acked = make(chan struct{})
+		t.mutex.Unlock()
+
+		t.wake()
+	}
+}
+
+// requeueInFlight возвращает неподтвержденные позиции в очередь после обрыва
+// потока, если за это время по заявке не пришла более свежая.
+func (t *telemetryStream) requeueInFlight() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for sequence, positions := range t.inFlight {
+		for _, position := range positions {
+			if _, ok := t.pending[position.ApplicationId]; ok {
+				continue
+			}
+			t.pending[position.ApplicationId] = position
+			t.order = append(t.order, position.ApplicationId)
+		}
+		delete(t.inFlight, sequence)
+	}
+}
@@ -0,0 +1,172 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/geo"
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+// stepWaypointActions выполняет очередь действий точки маршрута. Мгновенные
+// действия проходят за один такт, hover и orbit держат дрон у точки, пока не
+// истечет их длительность. Время действия идет тактами, как и полет: такты
+// на паузе сюда не попадают и длительность не сокращают.
+func (fp *FlightProcessor) stepWaypointActions(flight *structures.ActiveFlight, waypoint structures.RoutePoint) {
+	if flight.ActionRunning {
+		flight.ActionElapsed += fp.config.PositionUpdateInterval.Seconds()
+	}
+
+	for len(flight.Actions) > 0 {
+		action := flight.Actions[0]
+
+		if !flight.ActionRunning {
+			flight.ActionRunning = true
+			flight.ActionElapsed = 0
+			flight.ActionHeading = flight.CurrentPosition.Heading
+			if fp.actionDuration(flight, action) > 0 {
+				fp.notifyWaypointAction(flight, action, "started")
+			}
+		}
+
+		elapsed := flight.ActionElapsed
+		duration := fp.actionDuration(flight, action)
+
+		switch action.Type {
+		case structures.ActionHover:
+			flight.CurrentPosition.Altitude = waypoint.Altitude
+			flight.CurrentPosition.Speed = 0
+			flight.CurrentPosition.Timestamp = time.Now()
+		case structures.ActionOrbit:
+			flight.CurrentPosition = fp.orbitPosition(flight, waypoint, action, math.Min(elapsed, duration))
+		case structures.ActionChangeSpeed:
+			flight.SpeedMS = action.Speed
+		}
+
+		if elapsed < duration {
+			break
+		}
+
+		flight.Actions = flight.Actions[1:]
+		flight.ActionRunning = false
+		flight.ActionElapsed = 0
+		fp.notifyWaypointAction(flight, action, "completed")
+	}
+
+	fp.updateEstimatedEndTime(flight)
+
+	if fp.checkRestrictedZoneProximity(flight) {
+		return
+	}
+
+	err := fp.repo.SaveDronePosition(flight.CurrentPosition)
+	if err != nil {
+		log.Printf("Error saving drone position: %v", err)
+	}
+
+	fp.grpcClient.UpdateDronePosition(flight.CurrentPosition)
+}
+
+// actionDuration возвращает длительность действия в секундах. Orbit включает
+// выход из точки на окружность; обратно к точке дрон летит обычным шагом.
+func (fp *FlightProcessor) actionDuration(flight *structures.ActiveFlight, action structures.WaypointAction) float64 {
+	switch action.Type {
+	case structures.ActionHover:
+		return action.Seconds
+	case structures.ActionOrbit:
+		return (action.Radius + 2*math.Pi*action.Radius*orbitTurns(action)) / fp.flightSpeed(flight)
+	default:
+		return 0
+	}
+}
+
+// remainingActionTime возвращает, сколько секунд еще займут действия: остаток
+// текущей очереди и действия точек, до которых дрон не долетел.
+func (fp *FlightProcessor) remainingActionTime(flight *structures.ActiveFlight) float64 {
+	total := 0.0
+	for i, action := range flight.Actions {
+		duration := fp.actionDuration(flight, action)
+		if i == 0 && flight.ActionRunning {
+			duration = math.Max(duration-flight.ActionElapsed, 0)
+		}
+		total += duration
+	}
+
+	for i := flight.CurrentWaypoint; i < len(flight.Route); i++ {
+		// Действия этой точки уже в очереди или выполнены
+		if i == flight.ActionWaypoint {
+			continue
+		}
+		for _, action := range flight.Route[i].Actions {
+			total += fp.actionDuration(flight, action)
+		}
+	}
+
+	return total
+}
+
+// orbitPosition считает позицию на orbit через elapsed секунд: сначала дрон
+// удаляется от точки по курсу прибытия на радиус, затем облетает ее по часовой стрелке.
+func (fp *FlightProcessor) orbitPosition(flight *structures.ActiveFlight, center structures.RoutePoint, action structures.WaypointAction, elapsed float64) structures.DronePosition {
+	speed := fp.flightSpeed(flight)
+	travelled := speed * elapsed
+
+	entry := flight.ActionHeading
+	bearing, distance, heading := entry, travelled, entry
+	if travelled > action.Radius {
+		bearing = entry + (travelled-action.Radius)/action.Radius*180/math.Pi
+		distance = action.Radius
+		heading = bearing + 90
+	}
+
+	lat, lon := geo.Destination(center.Latitude, center.Longitude, math.Mod(bearing+360, 360), distance)
+
+	position := flight.CurrentPosition
+	position.Latitude = lat
+	position.Longitude = lon
+	position.Altitude = center.Altitude
+	position.Speed = speed
+	position.Heading = math.Mod(heading+360, 360)
+	position.Timestamp = time.Now()
+
+	return position
+}
+
+func orbitTurns(action structures.WaypointAction) float64 {
+	if action.Turns > 0 {
+		return action.Turns
+	}
+
+	return 1
+}
+
+// orbitRadius возвращает наибольший радиус orbit в точке, чтобы проверка зон
+// учитывала облет, а не только саму точку.
+func orbitRadius(point structures.RoutePoint) float64 {
+	radius := 0.0
+	for _, action := range point.Actions {
+		if action.Type == structures.ActionOrbit {
+			radius = math.Max(radius, action.Radius)
+		}
+	}
+
+	return radius
+}
+
+func (fp *FlightProcessor) notifyWaypointAction(flight *structures.ActiveFlight, action structures.WaypointAction, phase string) {
+	log.Printf("Drone %d waypoint %d: %s %s", flight.DroneId, flight.CurrentWaypoint, action.Type, phase)
+
+	fp.recordFlightEventDetails(flight, "waypoint_action", "",
+		fmt.Sprintf("Action %s %s at waypoint %d", action.Type, phase, flight.CurrentWaypoint),
+		structures.WaypointActionDetails{Waypoint: flight.CurrentWaypoint, Action: action, Phase: phase})
+
+	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
+	defer cancel()
+
+	if err := fp.grpcClient.NotifyWaypointAction(ctx, flight, action, phase); err != nil {
+		log.Printf("FAILED to send waypoint action notification: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/grpc"
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+const commandBatchSize = 50
+
+// applyFlightCommands выполняет команды пилотов из flight_commands. Вызывается
+// из цикла симуляции, поэтому меняет полеты без гонки с updateSingleFlight.
+func (fp *FlightProcessor) applyFlightCommands() {
+	commands, err := fp.repo.GetPendingFlightCommands(commandBatchSize)
+	if err != nil {
+		log.Printf("Error loading flight commands: %v", err)
+		return
+	}
+
+	for _, command := range commands {
+		fp.mutex.RLock()
+		flight := fp.activeFlights[command.ApplicationId]
+		fp.mutex.RUnlock()
+
+		switch {
+		case flight == nil:
+			fp.cancelInactiveApplication(command)
+		case command.Command == structures.FlightCommandAbort:
+			fp.abortFlight(flight, command.Reason)
+		case flight.ReturningHome:
+			log.Printf("Flight %d is already returning home, command %d ignored", flight.ApplicationId, command.Id)
+		default:
+			fp.returnHome(flight, command.Reason)
+		}
+
+		if err := fp.repo.MarkFlightCommandProcessed(command.Id); err != nil {
+			log.Printf("Error marking flight command %d processed: %v", command.Id, err)
+		}
+	}
+}
+
+// abortFlight прекращает полет на месте и сразу отменяет заявку.
+func (fp *FlightProcessor) abortFlight(flight *structures.ActiveFlight, reason string) {
+	log.Printf("ABORTING flight %d by pilot request: %s", flight.ApplicationId, reason)
+
+	fp.mutex.Lock()
+	delete(fp.activeFlights, flight.ApplicationId)
+	fp.mutex.Unlock()
+
+	fp.clearAlertsForFlight(flight.ApplicationId)
+
+	err := fp.changeStatus(flight.ApplicationId, structures.StatusCancelled, "Flight aborted by pilot", reason,
+		outboxEvents(grpc.FlightCompletedMessage(flight, "cancelled"))...)
+	if err != nil {
+		log.Printf("Error updating application status to cancelled: %v", err)
+	}
+
+	fp.recordFlightEvent(flight, "flight_cancelled", "", fmt.Sprintf("Flight aborted by pilot: %s", reason))
+}
+
+// returnHome разворачивает дрон к точке взлета. Пока дрон в воздухе, заявка
+// остается executing, и ее нельзя удалить; cancelled выставит completeFlight
+// после посадки.
+func (fp *FlightProcessor) returnHome(flight *structures.ActiveFlight, reason string) {
+	log.Printf("Flight %d cancelled by pilot, returning home: %s", flight.ApplicationId, reason)
+
+	here := structures.RoutePoint{
+		Latitude:      flight.CurrentPosition.Latitude,
+		Longitude:     flight.CurrentPosition.Longitude,
+		Altitude:      flight.CurrentPosition.Altitude,
+		ApplicationId: flight.ApplicationId,
+	}
+	home := flight.Route[0]
+	home.PointOrder = 1
+
+	flight.Route = []structures.RoutePoint{here, home}
+	flight.CurrentWaypoint = 1
+	flight.StartTime = time.Now()
+	flight.ScheduleStart = flight.StartTime
+	flight.ScheduleFlown = 0
+	flight.Actions = nil
+	flight.ActionWaypoint = 0
+	flight.ActionRunning = false
+	flight.ActionElapsed = 0
+	flight.State = structures.FlightStateActive
+	flight.DemoMode = false
+	flight.Deviations = nil
+	flight.ReturningHome = true
+	flight.CancelReason = reason
+	fp.updateEstimatedEndTime(flight)
+
+	err := fp.changeStatus(flight.ApplicationId, structures.StatusExecuting, "Flight cancelled by pilot, drone is returning home", reason)
+	if err != nil {
+		log.Printf("Error updating application status: %v", err)
+	}
+
+	fp.recordFlightEvent(flight, "flight_cancelled", "", fmt.Sprintf("Flight cancelled by pilot, returning home: %s", reason))
+}
+
+// cancelInactiveApplication обрабатывает команду для заявки, полета которой
+// нет в памяти, например после перезапуска процессора.
+func (fp *FlightProcessor) cancelInactiveApplication(command structures.FlightCommand) {
+	app, err := fp.repo.GetApplicationById(command.ApplicationId)
+	if err != nil {
+		log.Printf("Error loading application %d for command %d: %v", command.ApplicationId, command.Id, err)
+		return
+	}
+
+	if app.Status != structures.StatusExecuting {
+		log.Printf("Application %d is %s, command %d ignored", app.Id, app.Status, command.Id)
+		return
+	}
+
+	err = fp.changeStatus(app.Id, structures.StatusCancelled, "Flight cancelled by pilot", command.Reason)
+	if err != nil {
+		log.Printf("Error updating application status to cancelled: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/geo"
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+func (fp *FlightProcessor) checkRouteConformance(flight *structures.ActiveFlight) {
+	if len(flight.Route) == 0 {
+		return
+	}
+
+	report := fp.conformanceReport(flight)
+
+	fp.evaluateDeviation(flight, structures.DeviationLateral,
+		report.LateralDeviation > fp.config.ConformanceLateralM, report,
+		fmt.Sprintf("Lateral deviation %.1f m exceeds corridor of %.1f m on leg %d",
+			report.LateralDeviation, fp.config.ConformanceLateralM, report.Leg))
+
+	fp.evaluateDeviation(flight, structures.DeviationVertical,
+		report.VerticalDeviation > fp.config.ConformanceVerticalM, report,
+		fmt.Sprintf("Vertical deviation %.1f m exceeds tolerance of %.1f m on leg %d",
+			report.VerticalDeviation, fp.config.ConformanceVerticalM, report.Leg))
+
+	fp.evaluateDeviation(flight, structures.DeviationTime,
+		math.Abs(report.TimeDeviationSeconds) > fp.config.ConformanceTime.Seconds(), report,
+		fmt.Sprintf("Flight is %.0f s off the approved schedule (tolerance %.0f s)",
+			report.TimeDeviationSeconds, fp.config.ConformanceTime.Seconds()))
+}
+
+// conformanceReport сравнивает текущую позицию с активным участком маршрута.
+// Положительное отклонение по времени означает отставание от графика.
+func (fp *FlightProcessor) conformanceReport(flight *structures.ActiveFlight) structures.ConformanceReport {
+	position := flight.CurrentPosition
+
+	leg, alongLeg, flown := fp.routeDistanceFlown(flight)
+	to := flight.Route[leg]
+	from := to
+	if leg > 0 {
+		from = flight.Route[leg-1]
+	}
+
+	lateral := geo.DistanceToSegment(
+		position.Latitude, position.Longitude,
+		from.Latitude, from.Longitude,
+		to.Latitude, to.Longitude,
+	)
+
+	legLength := geo.Distance(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+
+	expectedAltitude := from.Altitude
+	if legLength > 0 {
+		expectedAltitude += (to.Altitude - from.Altitude) * alongLeg / legLength
+	}
+	// Тот же набор, что уже выполнил борт по разрешению climb
+	expectedAltitude += flight.ClimbOffset
+
+	timeDeviation := 0.0
+	if speed := fp.flightSpeed(flight); speed > 0 {
+		planned := flight.ScheduleFlown + speed*time.Since(flight.ScheduleStart).Seconds()
+		planned = math.Min(planned, fp.calculateRouteDistanceMeters(flight.Route))
+		timeDeviation = (planned - flown) / speed
+	}
+
+	return structures.ConformanceReport{
+		Leg:                  leg,
+		LateralDeviation:     lateral,
+		VerticalDeviation:    math.Abs(position.Altitude - expectedAltitude),
+		TimeDeviationSeconds: timeDeviation,
+	}
+}
+
+func (fp *FlightProcessor) evaluateDeviation(flight *structures.ActiveFlight, deviationType structures.DeviationType, outside bool, report structures.ConformanceReport, message string) {
+	if flight.Deviations == nil {
+		flight.Deviations = make(map[structures.DeviationType]bool)
+	}
+
+	wasOutside := flight.Deviations[deviationType]
+	if outside == wasOutside {
+		return
+	}
+
+	alertLevel := "WARNING"
+	eventType := "route_deviation_" + string(deviationType)
+	if outside {
+		flight.Deviations[deviationType] = true
+		log.Printf("NON-CONFORMANCE! Application %d: %s", flight.ApplicationId, message)
+	} else {
+		delete(flight.Deviations, deviationType)
+		alertLevel = "CLEAR"
+		eventType = "route_conformance_" + string(deviationType)
+		message = fmt.Sprintf("Flight is back within %s tolerance on leg %d", deviationType, report.Leg)
+		log.Printf("Application %d: %s", flight.ApplicationId, message)
+	}
+
+	fp.recordFlightEvent(flight, eventType, alertLevel, message)
+
+	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
+	defer cancel()
+
+	err := fp.grpcClient.NotifyRouteDeviation(ctx, flight, deviationType, alertLevel, report)
+	if err != nil {
+		log.Printf("FAILED to send route deviation alert for application %d: %v", flight.ApplicationId, err)
+	}
+}
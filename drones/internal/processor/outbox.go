@@ -0,0 +1,198 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/grpc"
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxMinBackoff  = time.Second
+	outboxMaxBackoff  = 5 * time.Minute
+	outboxSendTimeout = 10 * time.Second
+)
+
+// changeStatus меняет статус заявки и в той же транзакции ставит в outbox
+// уведомление о нем и сопутствующие события. Отправкой занимается runOutbox,
+// поэтому недоступность бэкенда не теряет уведомления.
+func (fp *FlightProcessor) changeStatus(applicationId int, status structures.Status, message, reason string, events ...structures.OutboxMessage) error {
+	return fp.changeRevisionStatus(applicationId, 0, status, message, reason, events...)
+}
+
+// changeRevisionStatus как changeStatus, но только если заявка все еще в
+// проверенной ревизии. Иначе возвращает repository.ErrStaleRevision: пилот
+// изменил заявку, она снова в pending и будет проверена заново.
+func (fp *FlightProcessor) changeRevisionStatus(applicationId, revision int, status structures.Status, message, reason string, events ...structures.OutboxMessage) error {
+	statusUpdate, err := grpc.StatusUpdateMessage(applicationId, status, message, reason)
+	if err != nil {
+		return err
+	}
+
+	messages := append([]structures.OutboxMessage{statusUpdate}, events...)
+	if err := fp.repo.UpdateApplicationStatusWithOutbox(applicationId, revision, status, reason, messages); err != nil {
+		return err
+	}
+
+	log.Printf("Queued %s status notification for application %d", status, applicationId)
+	fp.wakeOutbox()
+	return nil
+}
+
+// notifyStatusUpdate ставит в outbox уведомление о статусе, который не
+// записывается в заявку.
+func (fp *FlightProcessor) notifyStatusUpdate(applicationId int, status structures.Status, message, rejectionReason string) {
+	msg, err := grpc.StatusUpdateMessage(applicationId, status, message, rejectionReason)
+	if err != nil {
+		log.Printf("Error preparing status notification for application %d: %v", applicationId, err)
+		return
+	}
+
+	if err := fp.repo.EnqueueOutbox(msg); err != nil {
+		log.Printf("Error queueing status notification for application %d: %v", applicationId, err)
+		return
+	}
+
+	fp.wakeOutbox()
+}
+
+// enqueueEvents ставит в outbox события полета без смены статуса заявки.
+func (fp *FlightProcessor) enqueueEvents(applicationId int, messages ...structures.OutboxMessage) {
+	if len(messages) == 0 {
+		return
+	}
+
+	if err := fp.repo.EnqueueOutbox(messages...); err != nil {
+		log.Printf("Error queueing notifications for application %d: %v", applicationId, err)
+		return
+	}
+
+	fp.wakeOutbox()
+}
+
+// outboxEvents оборачивает результат конструктора уведомления для changeStatus.
+// Если сообщение не удалось подготовить, статус все равно меняется.
+func outboxEvents(msg structures.OutboxMessage, err error) []structures.OutboxMessage {
+	if err != nil {
+		log.Printf("Error preparing notification: %v", err)
+		return nil
+	}
+	return []structures.OutboxMessage{msg}
+}
+
+func (fp *FlightProcessor) wakeOutbox() {
+	select {
+	case fp.outboxKick <- struct{}{}:
+	default:
+	}
+}
+
+func (fp *FlightProcessor) runOutbox() {
+	defer close(fp.outboxDone)
+
+	ticker := time.NewTicker(fp.config.OutboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fp.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-fp.outboxKick:
+		}
+
+		fp.dispatchOutbox(fp.ctx)
+	}
+}
+
+// dispatchOutbox отправляет все сообщения, которым подошел срок. Следующее
+// сообщение заявки попадает в выборку только после доставки предыдущего,
+// поэтому выборка повторяется, пока что-то доставляется.
+func (fp *FlightProcessor) dispatchOutbox(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := fp.repo.GetDueOutboxMessages(outboxBatchSize)
+		if err != nil {
+			log.Printf("Error loading outbox: %v", err)
+			return
+		}
+
+		progressed := false
+		for _, msg := range messages {
+			if ctx.Err() != nil {
+				return
+			}
+			if fp.deliverOutboxMessage(ctx, msg) {
+				progressed = true
+			}
+		}
+
+		if !progressed {
+			return
+		}
+	}
+}
+
+// deliverOutboxMessage возвращает true, если сообщение больше не блокирует
+// очередь своей заявки: доставлено или отброшено.
+func (fp *FlightProcessor) deliverOutboxMessage(ctx context.Context, msg structures.OutboxMessage) bool {
+	sendCtx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	err := fp.grpcClient.Deliver(sendCtx, msg)
+	cancel()
+
+	attempts := msg.Attempts + 1
+
+	if err == nil {
+		if err := fp.repo.MarkOutboxDelivered(msg.Id); err != nil {
+			log.Printf("Error marking outbox message %d delivered: %v", msg.Id, err)
+			return false
+		}
+		log.Printf("Delivered %s notification for application %d (attempt %d)", msg.EventType, msg.ApplicationId, attempts)
+		return true
+	}
+
+	// Без явно заданного лимита попытки не кончаются: при долгой
+	// недоступности бэкенда сообщение ждет с задержкой не больше outboxMaxBackoff
+	maxAttempts := fp.config.OutboxMaxAttempts
+	if errors.Is(err, grpc.ErrMalformedMessage) || (maxAttempts > 0 && attempts >= maxAttempts) {
+		log.Printf("Giving up on %s notification for application %d after %d attempts: %v", msg.EventType, msg.ApplicationId, attempts, err)
+		if err := fp.repo.MarkOutboxFailed(msg.Id, err.Error()); err != nil {
+			log.Printf("Error marking outbox message %d failed: %v", msg.Id, err)
+			return false
+		}
+		fp.reportFailedOutbox()
+		return true
+	}
+
+	delay := outboxBackoff(attempts)
+	log.Printf("Failed to deliver %s notification for application %d (attempt %d), retrying in %s: %v", msg.EventType, msg.ApplicationId, attempts, delay, err)
+	if err := fp.repo.MarkOutboxRetry(msg.Id, time.Now().Add(delay), err.Error()); err != nil {
+		log.Printf("Error scheduling retry for outbox message %d: %v", msg.Id, err)
+	}
+	return false
+}
+
+// reportFailedOutbox поднимает тревогу об отброшенном уведомлении: клиенты
+// не узнают о событии, и состояние заявки нужно проверить вручную.
+func (fp *FlightProcessor) reportFailedOutbox() {
+	failed, err := fp.repo.CountFailedOutboxMessages()
+	if err != nil {
+		log.Printf("Error counting failed outbox messages: %v", err)
+		return
+	}
+	log.Printf("OUTBOX ALERT: %d notifications were dropped without delivery, see notification_outbox rows with status 'failed'", failed)
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxMinBackoff
+	for i := 1; i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxBackoff {
+		delay = outboxMaxBackoff
+	}
+	return delay
+}
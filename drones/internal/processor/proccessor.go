@@ -2,6 +2,8 @@ package processor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"github.com/qwaq-dev/drones/internal/config"
+	"github.com/qwaq-dev/drones/internal/geo"
 	"github.com/qwaq-dev/drones/internal/grpc"
 	"github.com/qwaq-dev/drones/internal/repository"
 	"github.com/qwaq-dev/drones/internal/structures"
@@ -27,6 +30,10 @@ type FlightProcessor struct {
 	zonesMutex      sync.RWMutex
 	sentAlerts      map[string]bool
 	alertsMutex     sync.RWMutex
+	conflicts       map[conflictPair]*structures.TrafficConflict
+	conflictsMutex  sync.Mutex
+	outboxKick      chan struct{}
+	outboxDone      chan struct{}
 }
 
 func New(repo *repository.Repository, grpcClient *grpc.NotificationClient, cfg *config.Config) *FlightProcessor {
@@ -40,6 +47,9 @@ func New(repo *repository.Repository, grpcClient *grpc.NotificationClient, cfg *
 		ctx:           ctx,
 		cancel:        cancel,
 		sentAlerts:    make(map[string]bool),
+		conflicts:     make(map[conflictPair]*structures.TrafficConflict),
+		outboxKick:    make(chan struct{}, 1),
+		outboxDone:    make(chan struct{}),
 	}
 }
 
@@ -51,6 +61,7 @@ func (fp *FlightProcessor) Start() {
 	go fp.processNewApplications()
 	go fp.simulateFlights()
 	go fp.periodicZoneUpdate()
+	go fp.runOutbox()
 }
 
 func (fp *FlightProcessor) Stop() {
@@ -62,6 +73,13 @@ func (fp *FlightProcessor) Stop() {
 		fp.forceCompleteFlight(flight, "system_shutdown")
 	}
 	fp.mutex.Unlock()
+
+	// Последняя попытка отправить накопленное; что не ушло, останется
+	// в outbox до следующего запуска.
+	<-fp.outboxDone
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	fp.dispatchOutbox(ctx)
 }
 
 func (fp *FlightProcessor) periodicZoneUpdate() {
@@ -117,7 +135,7 @@ func (fp *FlightProcessor) processNewApplications() {
 }
 
 func (fp *FlightProcessor) checkPendingApplications() {
-	applications, err := fp.repo.GetPendingApplications()
+	applications, err := fp.repo.GetPendingApplications(fp.config.SeriesValidationLead)
 	if err != nil {
 		log.Printf("Error getting pending applications: %v", err)
 		return
@@ -146,18 +164,16 @@ func (fp *FlightProcessor) processApplication(app structures.Application) {
 	default:
 	}
 
-	err := fp.repo.UpdateApplicationStatus(app.Id, structures.StatusProcessing, "")
+	err := fp.changeRevisionStatus(app.Id, app.Revision, structures.StatusProcessing, "Application is being processed and validated", "")
+	if errors.Is(err, repository.ErrStaleRevision) {
+		log.Printf("Application %d changed before processing, skipping stale revision %d", app.Id, app.Revision)
+		return
+	}
 	if err != nil {
 		log.Printf("Error updating application status: %v", err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(fp.ctx, 15*time.Second)
-	defer cancel()
-
-	log.Printf("Sending PROCESSING status notification for application %d", app.Id)
-	fp.notifyStatusUpdate(ctx, app.Id, structures.StatusProcessing, "Application is being processed and validated", "")
-
 	select {
 	case <-fp.ctx.Done():
 		return
@@ -168,38 +184,29 @@ func (fp *FlightProcessor) processApplication(app structures.Application) {
 
 	if approved {
 		log.Printf("Application %d APPROVED", app.Id)
-		err = fp.repo.UpdateApplicationStatus(app.Id, structures.StatusApproved, "")
+		err = fp.changeRevisionStatus(app.Id, app.Revision, structures.StatusApproved, "Application approved successfully. Flight will start shortly.", "")
+		if errors.Is(err, repository.ErrStaleRevision) {
+			log.Printf("Application %d changed during validation, approval discarded", app.Id)
+			return
+		}
 		if err != nil {
 			log.Printf("Error approving application: %v", err)
 			log.Printf("Sending REJECTED status notification for application %d due to DB error", app.Id)
-			fp.notifyStatusUpdate(ctx, app.Id, structures.StatusRejected, "Internal error occurred during approval", "Database update failed")
+			fp.notifyStatusUpdate(app.Id, structures.StatusRejected, "Internal error occurred during approval", "Database update failed")
 			return
 		}
 
-		log.Printf("Sending APPROVED status notification for application %d", app.Id)
-		fp.notifyStatusUpdate(ctx, app.Id, structures.StatusApproved, "Application approved successfully. Flight will start shortly.", "")
 		fp.startFlight(app)
 	} else {
 		log.Printf("Application %d REJECTED: %s", app.Id, reason)
-		err = fp.repo.UpdateApplicationStatus(app.Id, structures.StatusRejected, reason)
+		err = fp.changeRevisionStatus(app.Id, app.Revision, structures.StatusRejected, "Application rejected after validation", reason)
+		if errors.Is(err, repository.ErrStaleRevision) {
+			log.Printf("Application %d changed during validation, rejection discarded", app.Id)
+			return
+		}
 		if err != nil {
 			log.Printf("Error rejecting application: %v", err)
 		}
-
-		log.Printf("Sending REJECTED status notification for application %d with reason: %s", app.Id, reason)
-		fp.notifyStatusUpdate(ctx, app.Id, structures.StatusRejected, "Application rejected after validation", reason)
-	}
-}
-
-func (fp *FlightProcessor) notifyStatusUpdate(ctx context.Context, applicationId int, status structures.Status, message, rejectionReason string) {
-	log.Printf("Attempting to send gRPC notification: app_id=%d, status=%s, message=%s, reason=%s",
-		applicationId, status, message, rejectionReason)
-
-	err := fp.grpcClient.NotifyStatusUpdate(ctx, applicationId, status, message, rejectionReason)
-	if err != nil {
-		log.Printf("FAILED to send status update notification for application %d: %v", applicationId, err)
-	} else {
-		log.Printf("SUCCESS: Status update notification sent for application %d", applicationId)
 	}
 }
 
@@ -219,15 +226,13 @@ func (fp *FlightProcessor) validateFlight(app structures.Application) (bool, str
 		return false, "No destination point specified in the flight plan"
 	}
 
-	destination := destinationPoints[0]
-	log.Printf("Destination: lat=%.6f, lon=%.6f, alt=%.2f",
-		destination.Latitude, destination.Longitude, destination.Altitude)
-
-	if destination.Altitude < 0 || destination.Altitude > 500 {
-		return false, fmt.Sprintf("Invalid altitude: %.1f meters. Allowed range: 0-500 meters", destination.Altitude)
+	for _, point := range destinationPoints {
+		if point.Altitude < 0 || point.Altitude > 500 {
+			return false, fmt.Sprintf("Invalid altitude: %.1f meters. Allowed range: 0-500 meters", point.Altitude)
+		}
 	}
 
-	fullRoute := fp.createFullRoute(destination)
+	fullRoute := fp.createFullRoute(destinationPoints)
 	log.Printf("Created full route with %d points", len(fullRoute))
 
 	restrictedZones := fp.getRestrictedZones()
@@ -239,15 +244,17 @@ func (fp *FlightProcessor) validateFlight(app structures.Application) (bool, str
 func (fp *FlightProcessor) checkRouteAgainstZones(route []structures.RoutePoint, zones []structures.RestrictedZone) (bool, string) {
 	for _, zone := range zones {
 		log.Printf("Checking zone '%s': lat=%.6f, lon=%.6f, radius=%d m",
-			zone.Name, zone.Latitude, zone.Longtitude, zone.Radius)
+			zone.Name, zone.Latitude, zone.Longitude, zone.Radius)
 
 		for i, point := range route {
-			distance := fp.calculateDistanceMeters(point.Latitude, point.Longitude, zone.Latitude, zone.Longtitude)
+			// Граница зоны — юридическое ограничение, поэтому здесь расстояние
+			// считается по эллипсоиду, а не по сфере
+			distance := geo.EllipsoidalDistance(point.Latitude, point.Longitude, zone.Latitude, zone.Longitude)
 
 			log.Printf("Point %d (lat=%.6f, lon=%.6f) to zone '%s': distance=%.1f m, zone_radius=%d m",
 				i, point.Latitude, point.Longitude, zone.Name, distance, zone.Radius)
 
-			if distance <= float64(zone.Radius) {
+			if distance <= float64(zone.Radius)+orbitRadius(point) {
 				log.Printf("COLLISION! Point %d inside zone '%s' (distance: %.1f m <= radius: %d m)",
 					i, zone.Name, distance, zone.Radius)
 				return false, fmt.Sprintf("Flight route passes through restricted zone '%s'. Minimum distance required: %d meters", zone.Name, zone.Radius)
@@ -275,8 +282,10 @@ func (fp *FlightProcessor) checkLineIntersection(route []structures.RoutePoint,
 		start := route[i-1]
 		end := route[i]
 
-		minDistance := fp.distanceFromPointToLineSegment(
-			zone.Latitude, zone.Longtitude,
+		// Та же модель, что и для точек маршрута, иначе точка и прилегающий
+		// к ней участок могли бы по-разному оценить границу зоны
+		minDistance := geo.EllipsoidalDistanceToSegment(
+			zone.Latitude, zone.Longitude,
 			start.Latitude, start.Longitude,
 			end.Latitude, end.Longitude,
 		)
@@ -292,47 +301,26 @@ func (fp *FlightProcessor) checkLineIntersection(route []structures.RoutePoint,
 	return false
 }
 
-func (fp *FlightProcessor) distanceFromPointToLineSegment(px, py, x1, y1, x2, y2 float64) float64 {
-	A := px - x1
-	B := py - y1
-	C := x2 - x1
-	D := y2 - y1
-
-	dot := A*C + B*D
-	lenSq := C*C + D*D
-
-	if lenSq == 0 {
-		return fp.calculateDistanceMeters(px, py, x1, y1)
-	}
-
-	param := dot / lenSq
-
-	var xx, yy float64
-	if param < 0 {
-		xx, yy = x1, y1
-	} else if param > 1 {
-		xx, yy = x2, y2
-	} else {
-		xx = x1 + param*C
-		yy = y1 + param*D
-	}
-
-	return fp.calculateDistanceMeters(px, py, xx, yy)
-}
-
-func (fp *FlightProcessor) createFullRoute(destination structures.RoutePoint) []structures.RoutePoint {
+// createFullRoute добавляет к точкам заявки точку взлета. У заявки на
+// съемку площади точек много, у обычной — одна точка назначения.
+func (fp *FlightProcessor) createFullRoute(points []structures.RoutePoint) []structures.RoutePoint {
 	baseLocation := structures.RoutePoint{
 		Id:            0,
 		Latitude:      51.15545,
 		Longitude:     71.41216,
 		Altitude:      0.0,
 		PointOrder:    0,
-		ApplicationId: destination.ApplicationId,
+		ApplicationId: points[0].ApplicationId,
 	}
 
-	destination.PointOrder = 1
+	route := make([]structures.RoutePoint, 0, len(points)+1)
+	route = append(route, baseLocation)
+	for i, point := range points {
+		point.PointOrder = i + 1
+		route = append(route, point)
+	}
 
-	return []structures.RoutePoint{baseLocation, destination}
+	return route
 }
 
 func (fp *FlightProcessor) startFlight(app structures.Application) {
@@ -345,23 +333,19 @@ func (fp *FlightProcessor) startFlight(app structures.Application) {
 	destinationPoints, err := fp.repo.GetRouteByApplicationId(app.Id)
 	if err != nil {
 		log.Printf("Error loading destination for flight %d: %v", app.Id, err)
-		ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
-		defer cancel()
 		log.Printf("Sending CANCELLED status notification for application %d due to route loading error", app.Id)
-		fp.notifyStatusUpdate(ctx, app.Id, structures.StatusCancelled, "Failed to start flight", "Unable to load flight route")
+		fp.notifyStatusUpdate(app.Id, structures.StatusCancelled, "Failed to start flight", "Unable to load flight route")
 		return
 	}
 
 	if len(destinationPoints) == 0 {
 		log.Printf("No destination found for application %d", app.Id)
-		ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
-		defer cancel()
 		log.Printf("Sending CANCELLED status notification for application %d due to no destination", app.Id)
-		fp.notifyStatusUpdate(ctx, app.Id, structures.StatusCancelled, "Failed to start flight", "No destination found")
+		fp.notifyStatusUpdate(app.Id, structures.StatusCancelled, "Failed to start flight", "No destination found")
 		return
 	}
 
-	fullRoute := fp.createFullRoute(destinationPoints[0])
+	fullRoute := fp.createFullRoute(destinationPoints)
 
 	demoMode := app.Tested == 1
 
@@ -372,6 +356,7 @@ func (fp *FlightProcessor) startFlight(app structures.Application) {
 		Route:           fullRoute,
 		CurrentWaypoint: 0,
 		StartTime:       time.Now(),
+		ScheduleStart:   time.Now(),
 		Status:          structures.StatusExecuting,
 		CurrentPosition: structures.DronePosition{
 			ApplicationId: app.Id,
@@ -392,6 +377,24 @@ func (fp *FlightProcessor) startFlight(app structures.Application) {
 	flightTimeSeconds := totalDistance / fp.config.FlightSpeedMS
 	flightDuration := time.Duration(flightTimeSeconds) * time.Second
 	flight.EstimatedEndTime = flight.StartTime.Add(flightDuration)
+	flight.CurrentPosition.EstimatedEndTime = flight.EstimatedEndTime
+	flight.CurrentPosition.DistanceRemaining = totalDistance
+
+	// Статус меняется до регистрации полета: если пилот изменил или отменил
+	// одобренную заявку, старый маршрут не должен взлететь.
+	err = fp.changeRevisionStatus(app.Id, app.Revision, structures.StatusExecuting,
+		fmt.Sprintf("Flight started successfully. Estimated duration: %v", flightDuration), "",
+		outboxEvents(grpc.FlightStartedMessage(flight))...)
+	if errors.Is(err, repository.ErrStaleRevision) {
+		log.Printf("Application %d changed after approval, flight not started", app.Id)
+		return
+	}
+	if err != nil {
+		// Без записи в БД нет и уведомления FlightStarted в outbox: такой полет
+		// никто бы не увидел
+		log.Printf("Error updating application status to executing, flight %d not started: %v", app.Id, err)
+		return
+	}
 
 	fp.mutex.Lock()
 	fp.activeFlights[app.Id] = flight
@@ -399,29 +402,12 @@ func (fp *FlightProcessor) startFlight(app structures.Application) {
 
 	fp.clearAlertsForFlight(app.Id)
 
-	err = fp.repo.UpdateApplicationStatus(app.Id, structures.StatusExecuting, "")
-	if err != nil {
-		log.Printf("Error updating application status to executing: %v", err)
-	}
-
-	ctx, cancel := context.WithTimeout(fp.ctx, 15*time.Second)
-	defer cancel()
-
 	demoModeStatus := "OFF"
 	if demoMode {
 		demoModeStatus = "ON"
 	}
 
-	log.Printf("Sending EXECUTING status notification for application %d", app.Id)
-	fp.notifyStatusUpdate(ctx, app.Id, structures.StatusExecuting, fmt.Sprintf("Flight started successfully. Estimated duration: %v", flightDuration), "")
-
-	log.Printf("Sending flight started notification for application %d", app.Id)
-	err = fp.grpcClient.NotifyFlightStarted(ctx, flight)
-	if err != nil {
-		log.Printf("FAILED to send flight started notification: %v", err)
-	} else {
-		log.Printf("SUCCESS: Flight started notification sent for application %d", app.Id)
-	}
+	fp.recordFlightEvent(flight, "flight_started", "", fmt.Sprintf("Flight started. Estimated duration: %v", flightDuration))
 
 	log.Printf("Started flight for application %d (DEMO MODE: %s)", app.Id, demoModeStatus)
 	log.Printf("   Distance: %.1f m (%.2f km)", totalDistance, totalDistance/1000)
@@ -447,7 +433,7 @@ func (fp *FlightProcessor) checkDemoPause(flight *structures.ActiveFlight) bool
 		return true
 	}
 
-	if flight.State == structures.FlightStatePaused && flight.PauseStartTime != nil {
+	if flight.State == structures.FlightStatePaused && flight.PauseStartTime != nil && flight.Resolution != structures.ResolutionHold {
 		timeSincePause := time.Since(*flight.PauseStartTime)
 		if timeSincePause >= 10*time.Second {
 			fp.resumeFlight(flight, "Demo pause completed (tested=1)")
@@ -468,6 +454,8 @@ func (fp *FlightProcessor) pauseFlight(flight *structures.ActiveFlight, reason s
 	flight.PauseStartTime = &now
 	flight.CurrentPosition.Speed = 0
 
+	fp.recordFlightEvent(flight, "flight_paused", "", reason)
+
 	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
 	defer cancel()
 
@@ -485,7 +473,9 @@ func (fp *FlightProcessor) resumeFlight(flight *structures.ActiveFlight, reason
 	now := time.Now()
 	flight.State = structures.FlightStateActive
 	flight.PauseEndTime = &now
-	flight.CurrentPosition.Speed = fp.config.FlightSpeedMS
+	flight.CurrentPosition.Speed = fp.flightSpeed(flight)
+
+	fp.recordFlightEvent(flight, "flight_resumed", "", reason)
 
 	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
 	defer cancel()
@@ -501,6 +491,16 @@ func (fp *FlightProcessor) resumeFlight(flight *structures.ActiveFlight, reason
 func (fp *FlightProcessor) forceCompleteFlight(flight *structures.ActiveFlight, reason string) {
 	log.Printf("Force completing flight %d, reason: %s", flight.ApplicationId, reason)
 
+	if flight.ReturningHome {
+		err := fp.changeStatus(flight.ApplicationId, structures.StatusCancelled, "Flight cancelled by pilot, return home interrupted: "+reason, flight.CancelReason,
+			outboxEvents(grpc.FlightCompletedMessage(flight, reason))...)
+		if err != nil {
+			log.Printf("Error updating application status to cancelled: %v", err)
+		}
+		fp.recordFlightEvent(flight, "flight_completed", "", "Return home interrupted: "+reason)
+		return
+	}
+
 	var status structures.Status
 	var message string
 
@@ -516,24 +516,13 @@ func (fp *FlightProcessor) forceCompleteFlight(flight *structures.ActiveFlight,
 		message = "Flight completed successfully"
 	}
 
-	err := fp.repo.UpdateApplicationStatus(flight.ApplicationId, status, reason)
+	err := fp.changeStatus(flight.ApplicationId, status, message, reason,
+		outboxEvents(grpc.FlightCompletedMessage(flight, reason))...)
 	if err != nil {
 		log.Printf("Error updating application status: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	log.Printf("Sending %s status notification for application %d", status, flight.ApplicationId)
-	fp.notifyStatusUpdate(ctx, flight.ApplicationId, status, message, reason)
-
-	log.Printf("Sending flight completed notification for application %d", flight.ApplicationId)
-	err = fp.grpcClient.NotifyFlightCompleted(ctx, flight, reason)
-	if err != nil {
-		log.Printf("FAILED to send flight completed notification: %v", err)
-	} else {
-		log.Printf("SUCCESS: Flight completed notification sent for application %d", flight.ApplicationId)
-	}
+	fp.recordFlightEvent(flight, "flight_completed", "", message)
 }
 
 func (fp *FlightProcessor) stopFlightAndRemoveApplication(flight *structures.ActiveFlight, zone structures.RestrictedZone, distanceToBorder float64) {
@@ -542,19 +531,13 @@ func (fp *FlightProcessor) stopFlightAndRemoveApplication(flight *structures.Act
 
 	reason := fmt.Sprintf("Flight automatically stopped: drone approached within %.1f meters of restricted zone '%s'", distanceToBorder, zone.Name)
 
-	err := fp.repo.UpdateApplicationStatus(flight.ApplicationId, structures.StatusCancelled, reason)
-	if err != nil {
-		log.Printf("Error updating application status to cancelled: %v", err)
-	}
-
 	ctx, cancel := context.WithTimeout(fp.ctx, 15*time.Second)
 	defer cancel()
 
-	log.Printf("Sending CANCELLED status notification for application %d due to restricted zone", flight.ApplicationId)
-	fp.notifyStatusUpdate(ctx, flight.ApplicationId, structures.StatusCancelled, "Flight stopped for safety reasons", reason)
+	fp.recordFlightEvent(flight, "restricted_zone_alert", "DANGER", reason)
 
 	log.Printf("Sending restricted zone proximity alert for application %d", flight.ApplicationId)
-	err = fp.grpcClient.NotifyRestrictedZoneProximity(ctx, flight.ApplicationId, flight.DroneId, zone, "DANGER", distanceToBorder, flight.CurrentPosition)
+	err := fp.grpcClient.NotifyRestrictedZoneProximity(ctx, flight.ApplicationId, flight.DroneId, zone, "DANGER", distanceToBorder, flight.CurrentPosition)
 	if err != nil {
 		log.Printf("FAILED to send restricted zone alert: %v", err)
 	} else {
@@ -565,25 +548,32 @@ func (fp *FlightProcessor) stopFlightAndRemoveApplication(flight *structures.Act
 	delete(fp.activeFlights, flight.ApplicationId)
 	fp.mutex.Unlock()
 
-	log.Printf("Sending flight completed notification for application %d", flight.ApplicationId)
-	err = fp.grpcClient.NotifyFlightCompleted(ctx, flight, "restricted_zone")
+	// Отмена ставится в outbox после прямого предупреждения о зоне, чтобы
+	// клиенты получили их в этом порядке
+	err = fp.changeStatus(flight.ApplicationId, structures.StatusCancelled, "Flight stopped for safety reasons", reason,
+		outboxEvents(grpc.FlightCompletedMessage(flight, "restricted_zone"))...)
 	if err != nil {
-		log.Printf("FAILED to send flight completed notification: %v", err)
-	} else {
-		log.Printf("SUCCESS: Flight completed notification sent for application %d", flight.ApplicationId)
+		log.Printf("Error updating application status to cancelled: %v", err)
 	}
+
+	fp.recordFlightEvent(flight, "flight_completed", "", "Flight stopped for safety reasons")
 }
 
 func (fp *FlightProcessor) simulateFlights() {
 	ticker := time.NewTicker(fp.config.PositionUpdateInterval)
 	defer ticker.Stop()
 
+	commands := time.NewTicker(fp.config.CommandPollInterval)
+	defer commands.Stop()
+
 	for {
 		select {
 		case <-fp.ctx.Done():
 			return
 		case <-ticker.C:
 			fp.updateFlightPositions()
+		case <-commands.C:
+			fp.applyFlightCommands()
 		}
 	}
 }
@@ -604,6 +594,8 @@ func (fp *FlightProcessor) updateFlightPositions() {
 			fp.updateSingleFlight(flight)
 		}
 	}
+
+	fp.checkTrafficSeparation()
 }
 
 func (fp *FlightProcessor) updateSingleFlight(flight *structures.ActiveFlight) {
@@ -612,6 +604,10 @@ func (fp *FlightProcessor) updateSingleFlight(flight *structures.ActiveFlight) {
 	}
 
 	if flight.State == structures.FlightStatePaused {
+		// Дрон стоит, но ETA продолжает сдвигаться вместе с паузой
+		flight.CurrentPosition.Timestamp = time.Now()
+		fp.updateEstimatedEndTime(flight)
+		fp.grpcClient.UpdateDronePosition(flight.CurrentPosition)
 		return
 	}
 
@@ -622,15 +618,47 @@ func (fp *FlightProcessor) updateSingleFlight(flight *structures.ActiveFlight) {
 
 	currentWaypoint := flight.Route[flight.CurrentWaypoint]
 
-	newPosition := fp.calculateNewPosition(flight.CurrentPosition, currentWaypoint)
+	if flight.ActionWaypoint == flight.CurrentWaypoint && len(flight.Actions) > 0 {
+		actionPoint := currentWaypoint
+		actionPoint.Altitude += fp.stepClimbOffset(flight, currentWaypoint.Altitude)
+		fp.stepWaypointActions(flight, actionPoint)
+		return
+	}
+
+	// Навигация идет по высоте маршрута, набор по climb добавляется сверху
+	// с ограниченной вертикальной скоростью
+	current := flight.CurrentPosition
+	current.Altitude -= flight.ClimbOffset
+
+	var newPosition structures.DronePosition
+	holdingShort := false
+	if flight.Resolution == structures.ResolutionHoldShort && flight.HoldShortPoint != nil {
+		newPosition, holdingShort = fp.holdShortPosition(current, currentWaypoint, *flight.HoldShortPoint, fp.flightSpeed(flight))
+	} else {
+		newPosition = fp.calculateNewPosition(current, currentWaypoint, fp.flightSpeed(flight))
+	}
+	newPosition.Altitude += fp.stepClimbOffset(flight, newPosition.Altitude)
 
-	distance := fp.calculateDistanceMeters(
+	distance := geo.Distance(
 		newPosition.Latitude, newPosition.Longitude,
 		currentWaypoint.Latitude, currentWaypoint.Longitude,
 	)
 
-	if distance < 10.0 {
+	if holdingShort {
+		log.Printf("Drone %d holding short %.1f m before waypoint %d for traffic", flight.DroneId, distance, flight.CurrentWaypoint)
+	} else if distance < 10.0 && flight.ActionWaypoint != flight.CurrentWaypoint && len(currentWaypoint.Actions) > 0 {
+		log.Printf("Drone %d reached waypoint %d, %d actions queued", flight.DroneId, flight.CurrentWaypoint, len(currentWaypoint.Actions))
+		flight.ActionWaypoint = flight.CurrentWaypoint
+		flight.Actions = append([]structures.WaypointAction(nil), currentWaypoint.Actions...)
+		flight.ActionRunning = false
+		flight.ActionElapsed = 0
+	} else if distance < 10.0 {
 		log.Printf("Drone %d reached waypoint %d", flight.DroneId, flight.CurrentWaypoint)
+		if flight.ActionWaypoint == flight.CurrentWaypoint {
+			// Время на действия не считается отставанием от графика
+			flight.ScheduleStart = time.Now()
+			flight.ScheduleFlown = fp.calculateRouteDistanceMeters(flight.Route[:flight.CurrentWaypoint+1])
+		}
 		flight.CurrentWaypoint++
 		if flight.CurrentWaypoint >= len(flight.Route) {
 			fp.completeFlight(flight)
@@ -640,22 +668,23 @@ func (fp *FlightProcessor) updateSingleFlight(flight *structures.ActiveFlight) {
 
 	flight.CurrentPosition = newPosition
 	flight.CurrentPosition.RouteProgress = fp.calculateRouteProgress(flight)
+	fp.updateEstimatedEndTime(flight)
 
 	if fp.checkRestrictedZoneProximity(flight) {
 		return
 	}
 
+	// После orbit дрон возвращается в точку не по участку маршрута
+	if flight.ActionWaypoint != flight.CurrentWaypoint {
+		fp.checkRouteConformance(flight)
+	}
+
 	err := fp.repo.SaveDronePosition(flight.CurrentPosition)
 	if err != nil {
 		log.Printf("Error saving drone position: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(fp.ctx, 5*time.Second)
-	defer cancel()
-	err = fp.grpcClient.UpdateDronePosition(ctx, flight.CurrentPosition)
-	if err != nil {
-		log.Printf("Failed to send position update: %v", err)
-	}
+	fp.grpcClient.UpdateDronePosition(flight.CurrentPosition)
 
 	if time.Now().Unix()%2 == 0 {
 		stateInfo := ""
@@ -678,11 +707,11 @@ func (fp *FlightProcessor) checkRestrictedZoneProximity(flight *structures.Activ
 	restrictedZones := fp.getRestrictedZones()
 
 	for _, zone := range restrictedZones {
-		distanceToCenter := fp.calculateDistanceMeters(
+		distanceToCenter := geo.EllipsoidalDistance(
 			flight.CurrentPosition.Latitude,
 			flight.CurrentPosition.Longitude,
 			zone.Latitude,
-			zone.Longtitude,
+			zone.Longitude,
 		)
 
 		distanceToBorder := distanceToCenter - float64(zone.Radius)
@@ -702,31 +731,43 @@ func (fp *FlightProcessor) completeFlight(flight *structures.ActiveFlight) {
 	log.Printf("Completing flight for application %d", flight.ApplicationId)
 
 	flight.CurrentPosition.RouteProgress = 100.0
+	flight.CurrentPosition.DistanceRemaining = 0
+	flight.CurrentPosition.EstimatedEndTime = time.Now()
+	flight.EstimatedEndTime = flight.CurrentPosition.EstimatedEndTime
 
 	fp.clearAlertsForFlight(flight.ApplicationId)
 
-	err := fp.repo.UpdateApplicationStatus(flight.ApplicationId, structures.StatusCompleted, "")
+	err := fp.repo.SaveDronePosition(flight.CurrentPosition)
 	if err != nil {
-		log.Printf("Error updating application status to completed: %v", err)
+		log.Printf("Error saving final drone position: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
-	defer cancel()
-
-	log.Printf("Sending COMPLETED status notification for application %d", flight.ApplicationId)
-	fp.notifyStatusUpdate(ctx, flight.ApplicationId, structures.StatusCompleted, "Flight completed successfully. Drone has reached destination.", "")
-
-	err = fp.grpcClient.UpdateDronePosition(ctx, flight.CurrentPosition)
-	if err != nil {
-		log.Printf("Failed to send final position update: %v", err)
+	// Финальная позиция должна дойти до клиентов раньше события о завершении,
+	// поэтому статус меняется только после сброса телеметрии
+	fp.grpcClient.UpdateDronePosition(flight.CurrentPosition)
+	flushCtx, flushCancel := context.WithTimeout(fp.ctx, 2*time.Second)
+	if err := fp.grpcClient.FlushTelemetry(flushCtx); err != nil {
+		log.Printf("Failed to deliver final position update: %v", err)
 	}
+	flushCancel()
 
-	log.Printf("Sending flight completed notification for application %d", flight.ApplicationId)
-	err = fp.grpcClient.NotifyFlightCompleted(ctx, flight, "completed")
-	if err != nil {
-		log.Printf("FAILED to send flight completed notification: %v", err)
+	if flight.ReturningHome {
+		// Отмена пилотом завершается только после посадки дрона
+		err = fp.changeStatus(flight.ApplicationId, structures.StatusCancelled, "Flight cancelled by pilot, drone has returned home", flight.CancelReason,
+			outboxEvents(grpc.FlightCompletedMessage(flight, "returned_home"))...)
+		if err != nil {
+			log.Printf("Error updating application status to cancelled: %v", err)
+		}
+
+		fp.recordFlightEvent(flight, "flight_returned_home", "", "Drone returned home after cancellation")
 	} else {
-		log.Printf("SUCCESS: Flight completed notification sent for application %d", flight.ApplicationId)
+		err = fp.changeStatus(flight.ApplicationId, structures.StatusCompleted, "Flight completed successfully. Drone has reached destination.", "",
+			outboxEvents(grpc.FlightCompletedMessage(flight, "completed"))...)
+		if err != nil {
+			log.Printf("Error updating application status to completed: %v", err)
+		}
+
+		fp.recordFlightEvent(flight, "flight_completed", "", "Flight completed successfully")
 	}
 
 	fp.mutex.Lock()
@@ -734,37 +775,26 @@ func (fp *FlightProcessor) completeFlight(flight *structures.ActiveFlight) {
 	fp.mutex.Unlock()
 }
 
-func (fp *FlightProcessor) calculateNewPosition(current structures.DronePosition, target structures.RoutePoint) structures.DronePosition {
-	deltaLat := target.Latitude - current.Latitude
-	deltaLon := target.Longitude - current.Longitude
-	deltaAlt := target.Altitude - current.Altitude
-
-	distance := math.Sqrt(deltaLat*deltaLat + deltaLon*deltaLon)
+func (fp *FlightProcessor) calculateNewPosition(current structures.DronePosition, target structures.RoutePoint, speed float64) structures.DronePosition {
+	distance := geo.Distance(current.Latitude, current.Longitude, target.Latitude, target.Longitude)
+	step := speed * fp.config.PositionUpdateInterval.Seconds()
 
-	speedDegPerSec := fp.config.FlightSpeedMS / 111320.0
-
-	if distance < speedDegPerSec {
+	if distance <= step {
 		return structures.DronePosition{
 			ApplicationId: current.ApplicationId,
 			DroneId:       current.DroneId,
 			Latitude:      target.Latitude,
 			Longitude:     target.Longitude,
 			Altitude:      target.Altitude,
-			Speed:         fp.config.FlightSpeedMS,
+			Speed:         speed,
 			Heading:       current.Heading,
 			Timestamp:     time.Now(),
 		}
 	}
 
-	ratio := speedDegPerSec / distance
-	newLat := current.Latitude + deltaLat*ratio
-	newLon := current.Longitude + deltaLon*ratio
-	newAlt := current.Altitude + deltaAlt*ratio
-
-	heading := math.Atan2(deltaLon, deltaLat) * 180 / math.Pi
-	if heading < 0 {
-		heading += 360
-	}
+	heading := geo.InitialBearing(current.Latitude, current.Longitude, target.Latitude, target.Longitude)
+	newLat, newLon := geo.Destination(current.Latitude, current.Longitude, heading, step)
+	newAlt := current.Altitude + (target.Altitude-current.Altitude)*step/distance
 
 	return structures.DronePosition{
 		ApplicationId: current.ApplicationId,
@@ -772,12 +802,21 @@ func (fp *FlightProcessor) calculateNewPosition(current structures.DronePosition
 		Latitude:      newLat,
 		Longitude:     newLon,
 		Altitude:      newAlt,
-		Speed:         fp.config.FlightSpeedMS,
+		Speed:         speed,
 		Heading:       heading,
 		Timestamp:     time.Now(),
 	}
 }
 
+// flightSpeed возвращает крейсерскую скорость полета с учетом change_speed.
+func (fp *FlightProcessor) flightSpeed(flight *structures.ActiveFlight) float64 {
+	if flight.SpeedMS > 0 {
+		return flight.SpeedMS
+	}
+
+	return fp.config.FlightSpeedMS
+}
+
 func (fp *FlightProcessor) calculateRouteProgress(flight *structures.ActiveFlight) float64 {
 	if len(flight.Route) < 2 {
 		return 100.0
@@ -788,13 +827,9 @@ func (fp *FlightProcessor) calculateRouteProgress(flight *structures.ActiveFligh
 		return 100.0
 	}
 
-	startPoint := flight.Route[0]
-	currentDistanceFromStart := fp.calculateDistanceMeters(
-		startPoint.Latitude, startPoint.Longitude,
-		flight.CurrentPosition.Latitude, flight.CurrentPosition.Longitude,
-	)
+	_, _, flown := fp.routeDistanceFlown(flight)
 
-	progress := (currentDistanceFromStart / totalDistance) * 100
+	progress := (flown / totalDistance) * 100
 
 	if progress < 0 {
 		progress = 0
@@ -806,18 +841,55 @@ func (fp *FlightProcessor) calculateRouteProgress(flight *structures.ActiveFligh
 	return progress
 }
 
-func (fp *FlightProcessor) calculateDistanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
-	const R = 6371000
+// routeDistanceFlown возвращает активный участок, пройденную по нему дистанцию
+// и общее расстояние, пройденное вдоль маршрута с учетом всех предыдущих участков.
+func (fp *FlightProcessor) routeDistanceFlown(flight *structures.ActiveFlight) (int, float64, float64) {
+	if len(flight.Route) == 0 {
+		return 0, 0, 0
+	}
+
+	leg := flight.CurrentWaypoint
+	if leg >= len(flight.Route) {
+		leg = len(flight.Route) - 1
+	}
+	if leg == 0 {
+		return 0, 0, 0
+	}
+
+	from := flight.Route[leg-1]
+	to := flight.Route[leg]
+	position := flight.CurrentPosition
 
-	dLat := (lat2 - lat1) * math.Pi / 180
-	dLon := (lon2 - lon1) * math.Pi / 180
+	alongLeg := 0.0
+	legLength := geo.Distance(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	if legLength > 0 {
+		alongLeg = geo.AlongTrackDistance(
+			position.Latitude, position.Longitude,
+			from.Latitude, from.Longitude,
+			to.Latitude, to.Longitude,
+		)
+		alongLeg = math.Max(0, math.Min(alongLeg, legLength))
+	}
+
+	return leg, alongLeg, fp.calculateRouteDistanceMeters(flight.Route[:leg]) + alongLeg
+}
 
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*
-			math.Sin(dLon/2)*math.Sin(dLon/2)
+// updateEstimatedEndTime пересчитывает ETA по оставшейся длине маршрута и текущей
+// скорости, поэтому паузы и смена скорости сдвигают время прибытия. К полету
+// добавляется время оставшихся действий в точках.
+func (fp *FlightProcessor) updateEstimatedEndTime(flight *structures.ActiveFlight) {
+	speed := flight.CurrentPosition.Speed
+	if speed <= 0 {
+		speed = fp.flightSpeed(flight)
+	}
+
+	_, _, flown := fp.routeDistanceFlown(flight)
+	remaining := math.Max(fp.calculateRouteDistanceMeters(flight.Route)-flown, 0)
 
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	return R * c
+	seconds := remaining/speed + fp.remainingActionTime(flight)
+	flight.EstimatedEndTime = time.Now().Add(time.Duration(seconds * float64(time.Second)))
+	flight.CurrentPosition.DistanceRemaining = remaining
+	flight.CurrentPosition.EstimatedEndTime = flight.EstimatedEndTime
 }
 
 func (fp *FlightProcessor) calculateRouteDistanceMeters(route []structures.RoutePoint) float64 {
@@ -827,7 +899,7 @@ func (fp *FlightProcessor) calculateRouteDistanceMeters(route []structures.Route
 
 	totalDistance := 0.0
 	for i := 1; i < len(route); i++ {
-		distance := fp.calculateDistanceMeters(
+		distance := geo.Distance(
 			route[i-1].Latitude, route[i-1].Longitude,
 			route[i].Latitude, route[i].Longitude,
 		)
@@ -847,3 +919,36 @@ func (fp *FlightProcessor) clearAlertsForFlight(applicationId int) {
 		}
 	}
 }
+
+// recordFlightEvent сохраняет событие в историю полета для последующего разбора и воспроизведения.
+func (fp *FlightProcessor) recordFlightEvent(flight *structures.ActiveFlight, eventType, alertLevel, message string) {
+	fp.recordFlightEventDetails(flight, eventType, alertLevel, message, nil)
+}
+
+// recordFlightEventDetails сохраняет событие вместе с полями, которые нужны
+// для его воспроизведения.
+func (fp *FlightProcessor) recordFlightEventDetails(flight *structures.ActiveFlight, eventType, alertLevel, message string, details interface{}) {
+	var encoded json.RawMessage
+	if details != nil {
+		var err error
+		if encoded, err = json.Marshal(details); err != nil {
+			log.Printf("Error encoding flight event details for application %d: %v", flight.ApplicationId, err)
+		}
+	}
+
+	err := fp.repo.SaveFlightEvent(structures.FlightEvent{
+		ApplicationId: flight.ApplicationId,
+		DroneId:       flight.DroneId,
+		EventType:     eventType,
+		AlertLevel:    alertLevel,
+		Message:       message,
+		Details:       encoded,
+		Latitude:      flight.CurrentPosition.Latitude,
+		Longitude:     flight.CurrentPosition.Longitude,
+		Altitude:      flight.CurrentPosition.Altitude,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		log.Printf("Error saving flight event for application %d: %v", flight.ApplicationId, err)
+	}
+}
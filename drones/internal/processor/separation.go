@@ -0,0 +1,379 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/geo"
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+// Конфликт считается разрешенным, когда горизонтальное расстояние превышает
+// минимум с запасом, иначе набор высоты сразу снимал бы конфликт и борт
+// возвращался бы на эшелон прямо над другим дроном.
+const separationClearFactor = 1.2
+
+type conflictPair struct {
+	first  int
+	second int
+}
+
+func newConflictPair(a, b int) conflictPair {
+	if a > b {
+		a, b = b, a
+	}
+	return conflictPair{first: a, second: b}
+}
+
+type cellKey struct {
+	x int
+	y int
+}
+
+// spatialGrid раскладывает борта по квадратным ячейкам размером с минимум
+// горизонтального эшелонирования, поэтому сравнивать нужно только соседние ячейки.
+type spatialGrid struct {
+	cellSize   float64
+	projection *geo.ENU
+	cells      map[cellKey][]*structures.ActiveFlight
+}
+
+func newSpatialGrid(cellSize, originLat, originLon float64) *spatialGrid {
+	return &spatialGrid{
+		cellSize:   cellSize,
+		projection: geo.NewENU(originLat, originLon, 0),
+		cells:      make(map[cellKey][]*structures.ActiveFlight),
+	}
+}
+
+func (g *spatialGrid) cellOf(lat, lon float64) cellKey {
+	x, y, _ := g.projection.Forward(lat, lon, 0)
+
+	return cellKey{
+		x: int(math.Floor(x / g.cellSize)),
+		y: int(math.Floor(y / g.cellSize)),
+	}
+}
+
+func (g *spatialGrid) insert(flight *structures.ActiveFlight) {
+	key := g.cellOf(flight.CurrentPosition.Latitude, flight.CurrentPosition.Longitude)
+	g.cells[key] = append(g.cells[key], flight)
+}
+
+func (g *spatialGrid) neighbours(flight *structures.ActiveFlight) []*structures.ActiveFlight {
+	key := g.cellOf(flight.CurrentPosition.Latitude, flight.CurrentPosition.Longitude)
+
+	var result []*structures.ActiveFlight
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for _, other := range g.cells[cellKey{x: key.x + dx, y: key.y + dy}] {
+				if other.ApplicationId != flight.ApplicationId {
+					result = append(result, other)
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (fp *FlightProcessor) checkTrafficSeparation() {
+	if fp.config.SeparationHorizontalM <= 0 {
+		return
+	}
+
+	fp.mutex.RLock()
+	flights := make(map[int]*structures.ActiveFlight, len(fp.activeFlights))
+	for id, flight := range fp.activeFlights {
+		flights[id] = flight
+	}
+	fp.mutex.RUnlock()
+
+	grid := newSpatialGrid(fp.config.SeparationHorizontalM, fp.config.BaseLatitude, fp.config.BaseLongitude)
+	for _, flight := range flights {
+		grid.insert(flight)
+	}
+
+	for _, flight := range flights {
+		for _, other := range grid.neighbours(flight) {
+			if other.ApplicationId < flight.ApplicationId {
+				continue
+			}
+
+			horizontal := geo.Distance(
+				flight.CurrentPosition.Latitude, flight.CurrentPosition.Longitude,
+				other.CurrentPosition.Latitude, other.CurrentPosition.Longitude,
+			)
+			vertical := math.Abs(flight.CurrentPosition.Altitude - other.CurrentPosition.Altitude)
+
+			if horizontal < fp.config.SeparationHorizontalM && vertical < fp.config.SeparationVerticalM {
+				fp.handleTrafficConflict(flight, other, horizontal, vertical)
+			}
+		}
+	}
+
+	fp.clearResolvedConflicts(flights)
+}
+
+func (fp *FlightProcessor) handleTrafficConflict(a, b *structures.ActiveFlight, horizontal, vertical float64) {
+	pair := newConflictPair(a.ApplicationId, b.ApplicationId)
+
+	fp.conflictsMutex.Lock()
+	_, known := fp.conflicts[pair]
+	fp.conflictsMutex.Unlock()
+	if known {
+		return
+	}
+
+	yielding, priority := fp.lowerPriorityFlight(a, b)
+
+	log.Printf("SEPARATION LOSS! Application %d and %d: %.1f m horizontal, %.1f m vertical (minimum %.1f m / %.1f m)",
+		a.ApplicationId, b.ApplicationId, horizontal, vertical,
+		fp.config.SeparationHorizontalM, fp.config.SeparationVerticalM)
+
+	resolution := structures.ResolutionNone
+	if yielding.Resolution == "" || yielding.Resolution == structures.ResolutionNone {
+		resolution = fp.conflictResolution()
+		fp.applyResolution(yielding, priority, resolution)
+	}
+
+	fp.conflictsMutex.Lock()
+	fp.conflicts[pair] = &structures.TrafficConflict{
+		ApplicationId:         yielding.ApplicationId,
+		IntruderApplicationId: priority.ApplicationId,
+		HorizontalSeparation:  horizontal,
+		VerticalSeparation:    vertical,
+		Resolution:            resolution,
+		DetectedAt:            time.Now(),
+	}
+	fp.conflictsMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
+	defer cancel()
+
+	fp.sendTrafficConflict(ctx, yielding, priority, "DANGER", horizontal, vertical, resolution)
+	fp.sendTrafficConflict(ctx, priority, yielding, "DANGER", horizontal, vertical, structures.ResolutionNone)
+}
+
+func (fp *FlightProcessor) clearResolvedConflicts(flights map[int]*structures.ActiveFlight) {
+	fp.conflictsMutex.Lock()
+	pairs := make(map[conflictPair]*structures.TrafficConflict, len(fp.conflicts))
+	for pair, conflict := range fp.conflicts {
+		pairs[pair] = conflict
+	}
+	fp.conflictsMutex.Unlock()
+
+	for pair, conflict := range pairs {
+		yielding, yieldingActive := flights[conflict.ApplicationId]
+		priority, priorityActive := flights[conflict.IntruderApplicationId]
+
+		var horizontal, vertical float64
+		if yieldingActive && priorityActive {
+			horizontal = geo.Distance(
+				yielding.CurrentPosition.Latitude, yielding.CurrentPosition.Longitude,
+				priority.CurrentPosition.Latitude, priority.CurrentPosition.Longitude,
+			)
+			vertical = math.Abs(yielding.CurrentPosition.Altitude - priority.CurrentPosition.Altitude)
+
+			if horizontal < fp.config.SeparationHorizontalM*separationClearFactor {
+				continue
+			}
+		}
+
+		fp.conflictsMutex.Lock()
+		delete(fp.conflicts, pair)
+		stillInConflict := fp.hasConflictLocked(conflict.ApplicationId)
+		fp.conflictsMutex.Unlock()
+
+		log.Printf("Separation restored between application %d and %d", pair.first, pair.second)
+
+		if yieldingActive && !stillInConflict {
+			fp.releaseResolution(yielding)
+		}
+
+		if !yieldingActive || !priorityActive {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(fp.ctx, 10*time.Second)
+		fp.sendTrafficConflict(ctx, yielding, priority, "CLEAR", horizontal, vertical, structures.ResolutionNone)
+		fp.sendTrafficConflict(ctx, priority, yielding, "CLEAR", horizontal, vertical, structures.ResolutionNone)
+		cancel()
+	}
+}
+
+func (fp *FlightProcessor) hasConflictLocked(applicationId int) bool {
+	for _, conflict := range fp.conflicts {
+		if conflict.ApplicationId == applicationId {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerPriorityFlight возвращает борт, который уступает: приоритет у полета,
+// начавшегося раньше, при равенстве — у заявки с меньшим номером.
+func (fp *FlightProcessor) lowerPriorityFlight(a, b *structures.ActiveFlight) (*structures.ActiveFlight, *structures.ActiveFlight) {
+	if a.StartTime.Before(b.StartTime) {
+		return b, a
+	}
+	if b.StartTime.Before(a.StartTime) {
+		return a, b
+	}
+	if a.ApplicationId < b.ApplicationId {
+		return b, a
+	}
+	return a, b
+}
+
+func (fp *FlightProcessor) conflictResolution() structures.ConflictResolution {
+	switch resolution := structures.ConflictResolution(fp.config.ConflictResolution); resolution {
+	case structures.ResolutionHold, structures.ResolutionClimb, structures.ResolutionHoldShort:
+		return resolution
+	default:
+		return structures.ResolutionNone
+	}
+}
+
+func (fp *FlightProcessor) applyResolution(flight, priority *structures.ActiveFlight, resolution structures.ConflictResolution) {
+	flight.Resolution = resolution
+
+	switch resolution {
+	case structures.ResolutionHold:
+		log.Printf("Resolution for application %d: HOLD until separation with application %d is restored",
+			flight.ApplicationId, priority.ApplicationId)
+		if flight.State == structures.FlightStateActive {
+			fp.pauseFlight(flight, fmt.Sprintf("Holding for traffic: application %d", priority.ApplicationId))
+		}
+	case structures.ResolutionClimb:
+		flight.AltitudeOffset = fp.config.SeparationVerticalM
+		log.Printf("Resolution for application %d: CLIMB %.1f m above route altitude",
+			flight.ApplicationId, flight.AltitudeOffset)
+	case structures.ResolutionHoldShort:
+		flight.HoldShortPoint = fp.holdShortPoint(flight, priority)
+		log.Printf("Resolution for application %d: HOLD SHORT before closest approach with application %d",
+			flight.ApplicationId, priority.ApplicationId)
+	}
+}
+
+// holdShortPoint выбирает, где борт ждет при hold_short: на текущем участке за
+// минимум горизонтального эшелонирования до точки наибольшего сближения с
+// intruder. Если сближение уже позади или ближе минимума, борт встает на месте.
+// Высота точки — высота маршрута, без набора по climb.
+func (fp *FlightProcessor) holdShortPoint(flight, intruder *structures.ActiveFlight) *structures.RoutePoint {
+	if flight.CurrentWaypoint >= len(flight.Route) {
+		return nil
+	}
+
+	position := flight.CurrentPosition
+	target := flight.Route[flight.CurrentWaypoint]
+	remaining := geo.Distance(position.Latitude, position.Longitude, target.Latitude, target.Longitude)
+	track := geo.InitialBearing(position.Latitude, position.Longitude, target.Latitude, target.Longitude)
+	speed := fp.flightSpeed(flight)
+
+	// Сближение считается в плоскости, касательной в точке борта, при
+	// неизменных скоростях и курсах обоих бортов
+	projection := geo.NewENU(position.Latitude, position.Longitude, 0)
+	east, north, _ := projection.Forward(intruder.CurrentPosition.Latitude, intruder.CurrentPosition.Longitude, 0)
+	ownEast, ownNorth := velocity(speed, track)
+	intruderEast, intruderNorth := velocity(intruder.CurrentPosition.Speed, intruder.CurrentPosition.Heading)
+	relativeEast, relativeNorth := intruderEast-ownEast, intruderNorth-ownNorth
+
+	closestApproach := 0.0
+	if squared := relativeEast*relativeEast + relativeNorth*relativeNorth; squared > 0 {
+		closestApproach = math.Max(-(east*relativeEast+north*relativeNorth)/squared, 0)
+	}
+
+	distance := math.Min(math.Max(speed*closestApproach-fp.config.SeparationHorizontalM, 0), remaining)
+	lat, lon := geo.Destination(position.Latitude, position.Longitude, track, distance)
+
+	altitude := position.Altitude - flight.ClimbOffset
+	if remaining > 0 {
+		altitude += (target.Altitude - altitude) * distance / remaining
+	}
+
+	return &structures.RoutePoint{
+		Latitude:      lat,
+		Longitude:     lon,
+		Altitude:      altitude,
+		ApplicationId: flight.ApplicationId,
+	}
+}
+
+// velocity раскладывает скорость по курсу на составляющие восток и север.
+func velocity(speed, heading float64) (float64, float64) {
+	radians := heading * math.Pi / 180
+	return speed * math.Sin(radians), speed * math.Cos(radians)
+}
+
+// holdShortPosition ведет борт к точке маршрута, но останавливает его в точке
+// удержания hold. Второе значение сообщает, что борт стоит на удержании.
+func (fp *FlightProcessor) holdShortPosition(current structures.DronePosition, target, hold structures.RoutePoint, speed float64) (structures.DronePosition, bool) {
+	available := geo.Distance(current.Latitude, current.Longitude, hold.Latitude, hold.Longitude)
+	step := speed * fp.config.PositionUpdateInterval.Seconds()
+
+	if available > step {
+		return fp.calculateNewPosition(current, target, speed), false
+	}
+
+	position := current
+	position.Speed = 0
+	position.Timestamp = time.Now()
+
+	if available > 0 {
+		position.Heading = geo.InitialBearing(current.Latitude, current.Longitude, hold.Latitude, hold.Longitude)
+		position.Latitude = hold.Latitude
+		position.Longitude = hold.Longitude
+		position.Altitude = hold.Altitude
+	}
+
+	return position, true
+}
+
+// stepClimbOffset приближает набранную над маршрутом высоту к заданной
+// разрешением climb не быстрее ClimbRateMS и возвращает ее. routeAltitude —
+// высота маршрута в этом такте: выше 500 м борт не поднимается.
+func (fp *FlightProcessor) stepClimbOffset(flight *structures.ActiveFlight, routeAltitude float64) float64 {
+	target := math.Max(math.Min(flight.AltitudeOffset, 500-routeAltitude), 0)
+	step := fp.config.ClimbRateMS * fp.config.PositionUpdateInterval.Seconds()
+
+	switch {
+	case step <= 0:
+		flight.ClimbOffset = target
+	case flight.ClimbOffset < target:
+		flight.ClimbOffset = math.Min(flight.ClimbOffset+step, target)
+	case flight.ClimbOffset > target:
+		flight.ClimbOffset = math.Max(flight.ClimbOffset-step, target)
+	}
+
+	return flight.ClimbOffset
+}
+
+func (fp *FlightProcessor) releaseResolution(flight *structures.ActiveFlight) {
+	resolution := flight.Resolution
+	flight.Resolution = ""
+	flight.AltitudeOffset = 0
+	flight.HoldShortPoint = nil
+
+	switch resolution {
+	case structures.ResolutionHold:
+		if flight.State == structures.FlightStatePaused {
+			fp.resumeFlight(flight, "Traffic separation restored")
+		}
+	case structures.ResolutionClimb, structures.ResolutionHoldShort:
+		log.Printf("Released %s resolution for application %d", resolution, flight.ApplicationId)
+	}
+}
+
+func (fp *FlightProcessor) sendTrafficConflict(ctx context.Context, flight, intruder *structures.ActiveFlight, alertLevel string, horizontal, vertical float64, resolution structures.ConflictResolution) {
+	fp.recordFlightEvent(flight, "traffic_conflict", alertLevel,
+		fmt.Sprintf("Traffic application %d at %.1f m horizontal, %.1f m vertical (resolution: %s)",
+			intruder.ApplicationId, horizontal, vertical, resolution))
+
+	err := fp.grpcClient.NotifyTrafficConflict(ctx, flight, intruder, alertLevel, horizontal, vertical, resolution)
+	if err != nil {
+		log.Printf("FAILED to send traffic conflict alert for application %d: %v", flight.ApplicationId, err)
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+// GetPendingFlightCommands возвращает необработанные команды в порядке поступления.
+func (r *Repository) GetPendingFlightCommands(limit int) ([]structures.FlightCommand, error) {
+	query := `
+		SELECT command_id, application_id, command, reason
+		FROM flight_commands
+		WHERE processed_at IS NULL
+		ORDER BY command_id
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flight commands: %w", err)
+	}
+	defer rows.Close()
+
+	var commands []structures.FlightCommand
+	for rows.Next() {
+		var command structures.FlightCommand
+		if err := rows.Scan(&command.Id, &command.ApplicationId, &command.Command, &command.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan flight command: %w", err)
+		}
+		commands = append(commands, command)
+	}
+
+	return commands, rows.Err()
+}
+
+func (r *Repository) MarkFlightCommandProcessed(id int64) error {
+	query := `
+		UPDATE flight_commands 
+		SET processed_at = ? 
+		WHERE command_id = ?
+	`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qwaq-dev/drones/internal/structures"
+)
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// ErrStaleRevision — заявку изменили после того, как процессор ее прочитал.
+var ErrStaleRevision = errors.New("application revision has changed")
+
+// UpdateApplicationStatusWithOutbox меняет статус заявки и в той же
+// транзакции кладет уведомления в outbox: либо сохраняется и то и другое,
+// либо ничего. Ненулевой revision — ревизия, которую проверял процессор:
+// если пилот успел изменить заявку, статус не меняется и возвращается
+// ErrStaleRevision.
+func (r *Repository) UpdateApplicationStatusWithOutbox(id, revision int, status structures.Status, reason string, messages []structures.OutboxMessage) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if revision != 0 {
+		var current int
+		err := tx.QueryRow("SELECT revision FROM Application WHERE application_id = ? FOR UPDATE", id).Scan(&current)
+		if err != nil {
+			return fmt.Errorf("failed to lock application: %w", err)
+		}
+		if current != revision {
+			return ErrStaleRevision
+		}
+	}
+
+	query := `
+		UPDATE Application 
+		SET status = ?, rejection_reason = ?, last_update = NOW() 
+		WHERE application_id = ?
+	`
+	if _, err := tx.Exec(query, status, reason, id); err != nil {
+		return fmt.Errorf("failed to update application status: %w", err)
+	}
+
+	for _, msg := range messages {
+		if err := insertOutboxMessage(tx, msg); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status change: %w", err)
+	}
+	return nil
+}
+
+// EnqueueOutbox сохраняет уведомления, не связанные со сменой статуса.
+func (r *Repository) EnqueueOutbox(messages ...structures.OutboxMessage) error {
+	for _, msg := range messages {
+		if err := insertOutboxMessage(r.db, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertOutboxMessage(db execer, msg structures.OutboxMessage) error {
+	query := `
+		INSERT INTO notification_outbox 
+		(idempotency_key, application_id, event_type, payload, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := db.Exec(query, msg.IdempotencyKey, msg.ApplicationId, msg.EventType, msg.Payload, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox message: %w", err)
+	}
+	return nil
+}
+
+// GetDueOutboxMessages возвращает сообщения, которые пора отправить. По каждой
+// заявке берется только самое старое неотправленное сообщение, поэтому
+// следующее не уйдет раньше, чем доставлено предыдущее.
+func (r *Repository) GetDueOutboxMessages(limit int) ([]structures.OutboxMessage, error) {
+	query := `
+		SELECT o.outbox_id, o.idempotency_key, o.application_id, o.event_type, o.payload, o.attempts
+		FROM notification_outbox o
+		WHERE o.status = 'pending' AND o.next_attempt_at <= ?
+		AND NOT EXISTS (
+			SELECT 1 FROM notification_outbox p
+			WHERE p.application_id = o.application_id
+			AND p.status = 'pending'
+			AND p.outbox_id < o.outbox_id
+		)
+		ORDER BY o.outbox_id
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, time.Now(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []structures.OutboxMessage
+	for rows.Next() {
+		var msg structures.OutboxMessage
+		err := rows.Scan(&msg.Id, &msg.IdempotencyKey, &msg.ApplicationId, &msg.EventType, &msg.Payload, &msg.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *Repository) MarkOutboxDelivered(id int64) error {
+	query := `
+		UPDATE notification_outbox 
+		SET status = 'delivered', attempts = attempts + 1, last_error = NULL, delivered_at = ? 
+		WHERE outbox_id = ?
+	`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
+
+// MarkOutboxRetry откладывает сообщение до nextAttempt после неудачной попытки.
+func (r *Repository) MarkOutboxRetry(id int64, nextAttempt time.Time, lastError string) error {
+	query := `
+		UPDATE notification_outbox 
+		SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? 
+		WHERE outbox_id = ?
+	`
+	_, err := r.db.Exec(query, nextAttempt, lastError, id)
+	return err
+}
+
+// MarkOutboxFailed прекращает попытки доставки и освобождает очередь заявки.
+func (r *Repository) MarkOutboxFailed(id int64, lastError string) error {
+	query := `
+		UPDATE notification_outbox 
+		SET status = 'failed', attempts = attempts + 1, last_error = ? 
+		WHERE outbox_id = ?
+	`
+	_, err := r.db.Exec(query, lastError, id)
+	return err
+}
+
+// CountFailedOutboxMessages возвращает число уведомлений, доставка которых прекращена.
+func (r *Repository) CountFailedOutboxMessages() (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM notification_outbox WHERE status = 'failed'").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failed outbox messages: %w", err)
+	}
+	return count, nil
+}
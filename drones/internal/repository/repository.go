@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -76,17 +77,22 @@ func (r *Repository) UpdateApplicationTested(id int, tested int) error {
 	return nil
 }
 
-func (r *Repository) GetPendingApplications() ([]structures.Application, error) {
+// GetPendingApplications возвращает заявки, ожидающие проверки. Вхождения
+// регулярных заявок попадают в выборку только за lead до своего начала,
+// чтобы проверяться по зонам, действующим в момент полета.
+func (r *Repository) GetPendingApplications(lead time.Duration) ([]structures.Application, error) {
 	query := `
 		SELECT application_id, start_date, end_date, status, 
 		       COALESCE(rejection_reason, '') as rejection_reason,
 		       COALESCE(restricted_zone_check, 0) as restricted_zone_check,
-		       created_at, last_update, pilot_id, drone_id, tested
+		       created_at, last_update, pilot_id, drone_id, tested, revision,
+		       COALESCE(series_id, 0) as series_id
 		FROM Application 
 		WHERE status = 'pending'
+		AND (series_id IS NULL OR start_date <= NOW() + INTERVAL ? SECOND)
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, int(lead.Seconds()))
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +106,8 @@ func (r *Repository) GetPendingApplications() ([]structures.Application, error)
 		err := rows.Scan(
 			&app.Id, &app.Start_date, &app.End_date, &app.Status,
 			&app.Rejection_reason, &app.Restricted_zone_check,
-			&createdAtStr, &lastUpdateStr, &app.Pilot_id, &app.Drone_id, &app.Tested,
+			&createdAtStr, &lastUpdateStr, &app.Pilot_id, &app.Drone_id, &app.Tested, &app.Revision,
+			&app.SeriesId,
 		)
 		if err != nil {
 			log.Printf("Error scanning application: %v", err)
@@ -137,7 +144,7 @@ func (r *Repository) UpdateApplicationStatus(id int, status structures.Status, r
 
 func (r *Repository) GetRouteByApplicationId(applicationId int) ([]structures.RoutePoint, error) {
 	query := `
-		SELECT route_id, latitude, longtitude, altitude, point_order, application_id
+		SELECT route_id, latitude, longtitude, altitude, point_order, application_id, actions
 		FROM Route 
 		WHERE application_id = ? 
 		ORDER BY point_order ASC
@@ -152,14 +159,20 @@ func (r *Repository) GetRouteByApplicationId(applicationId int) ([]structures.Ro
 	var route []structures.RoutePoint
 	for rows.Next() {
 		var point structures.RoutePoint
+		var actions []byte
 		err := rows.Scan(
 			&point.Id, &point.Latitude, &point.Longitude,
-			&point.Altitude, &point.PointOrder, &point.ApplicationId,
+			&point.Altitude, &point.PointOrder, &point.ApplicationId, &actions,
 		)
 		if err != nil {
 			log.Printf("Error scanning route point: %v", err)
 			continue
 		}
+		if len(actions) > 0 {
+			if err := json.Unmarshal(actions, &point.Actions); err != nil {
+				return nil, fmt.Errorf("failed to parse actions of route point %d: %w", point.Id, err)
+			}
+		}
 		route = append(route, point)
 	}
 
@@ -182,7 +195,7 @@ func (r *Repository) GetRestrictedZones() ([]structures.RestrictedZone, error) {
 	for rows.Next() {
 		var zone structures.RestrictedZone
 		err := rows.Scan(
-			&zone.Id, &zone.Latitude, &zone.Longtitude,
+			&zone.Id, &zone.Latitude, &zone.Longitude,
 			&zone.Altitude, &zone.Name, &zone.Radius,
 		)
 		if err != nil {
@@ -195,25 +208,38 @@ func (r *Repository) GetRestrictedZones() ([]structures.RestrictedZone, error) {
 	return zones, nil
 }
 
+// SaveDronePosition дописывает точку в историю телеметрии полета,
+// чтобы весь пройденный трек оставался доступным для воспроизведения.
 func (r *Repository) SaveDronePosition(position structures.DronePosition) error {
 	query := `
-		INSERT INTO drone_positions 
-		(application_id, drone_id, latitude, longitude, altitude, speed, heading, timestamp, route_progress)
+		INSERT INTO drone_telemetry 
+		(application_id, drone_id, latitude, longitude, altitude, speed, heading, route_progress, timestamp)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-		latitude = VALUES(latitude),
-		longitude = VALUES(longitude),
-		altitude = VALUES(altitude),
-		speed = VALUES(speed),
-		heading = VALUES(heading),
-		timestamp = VALUES(timestamp),
-		route_progress = VALUES(route_progress)
 	`
 
 	_, err := r.db.Exec(query,
 		position.ApplicationId, position.DroneId,
 		position.Latitude, position.Longitude, position.Altitude,
-		position.Speed, position.Heading, position.Timestamp, position.RouteProgress,
+		position.Speed, position.Heading, position.RouteProgress, position.Timestamp,
+	)
+	return err
+}
+
+func (r *Repository) SaveFlightEvent(event structures.FlightEvent) error {
+	query := `
+		INSERT INTO flight_history 
+		(application_id, drone_id, event_type, alert_level, message, details, latitude, longitude, altitude, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var details interface{}
+	if len(event.Details) > 0 {
+		details = []byte(event.Details)
+	}
+
+	_, err := r.db.Exec(query,
+		event.ApplicationId, event.DroneId, event.EventType, event.AlertLevel, event.Message, details,
+		event.Latitude, event.Longitude, event.Altitude, event.CreatedAt,
 	)
 	return err
 }
@@ -5,15 +5,17 @@ import "time"
 type Application struct {
 	Id                    int       `json:"application_id"`
 	Start_date            string    `json:"start_date"`
-	End_date              string    `json"end_date"`
+	End_date              string    `json:"end_date"`
 	Status                Status    `json:"status"`
-	Rejection_reason      string    `json:"rejection_reason, omitempty"`
-	Restricted_zone_check int       `json:"restricted_zone_check, omitempty"`
-	Created_at            time.Time `json:"created_at, omitempty"`
-	Last_update           time.Time `json:"last_update, omitempty"`
+	Rejection_reason      string    `json:"rejection_reason,omitempty"`
+	Restricted_zone_check int       `json:"restricted_zone_check,omitempty"`
+	Created_at            time.Time `json:"created_at,omitempty"`
+	Last_update           time.Time `json:"last_update,omitempty"`
 	Pilot_id              int       `json:"pilot_id"`
 	Drone_id              int       `json:"drone_id"`
 	Tested                int       `json:"tested" db:"tested"`
+	Revision              int       `json:"revision"`
+	SeriesId              int       `json:"series_id,omitempty"`
 }
 
 type CreateApplicationRequest struct {
@@ -27,7 +29,7 @@ type CreateApplicationRequest struct {
 	PilotId             int       `json:"pilot_id"`
 	DroneId             int       `json:"drone_id"`
 	Latitude            float64   `json:"latitude"`
-	Longtitude          float64   `json:"longtitude"`
+	Longitude           float64   `json:"longitude"`
 	Altitude            float64   `json:"altitude"`
 	PointOrder          int       `json:"point_order,omitempty"`
 }
@@ -39,6 +41,6 @@ type AllPitlotsApl struct {
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	Serialnumber string    `json:"serial_number"`
 	Latitude     float64   `json:"latitude"`
-	Longtitude   float64   `json:"longtitude"`
+	Longitude    float64   `json:"longitude"`
 	Altitude     float64   `json:"altitude"`
 }
@@ -0,0 +1,19 @@
+package structures
+
+type FlightCommandType string
+
+const (
+	// FlightCommandAbort — прервать полет на месте.
+	FlightCommandAbort FlightCommandType = "abort"
+	// FlightCommandReturnHome — вернуть дрон в точку взлета.
+	FlightCommandReturnHome FlightCommandType = "return_home"
+)
+
+// FlightCommand — команда пилота выполняемому полету, поставленная бэкендом
+// в таблицу flight_commands.
+type FlightCommand struct {
+	Id            int64
+	ApplicationId int
+	Command       FlightCommandType
+	Reason        string
+}
@@ -1,6 +1,9 @@
 package structures
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Status string
 type FlightState string
@@ -29,6 +32,9 @@ type DronePosition struct {
 	Heading       float64   `json:"heading"`
 	Timestamp     time.Time `json:"timestamp"`
 	RouteProgress float64   `json:"route_progress"`
+
+	EstimatedEndTime  time.Time `json:"estimated_end_time"`
+	DistanceRemaining float64   `json:"distance_remaining"`
 }
 
 type ActiveFlight struct {
@@ -48,14 +54,125 @@ type ActiveFlight struct {
 	PauseEndTime    *time.Time  `json:"pause_end_time,omitempty"`
 	FlightStartTime time.Time   `json:"flight_start_time"` // Время начала полета для расчета паузы
 	DemoMode        bool        `json:"demo_mode"`         // Флаг демо-режима
+
+	// Активное разрешение конфликта с другим бортом. AltitudeOffset — заданный
+	// climb набор над маршрутом, ClimbOffset — набранный к этому такту.
+	// HoldShortPoint — где борт ждет при hold_short
+	Resolution     ConflictResolution `json:"resolution,omitempty"`
+	AltitudeOffset float64            `json:"altitude_offset,omitempty"`
+	ClimbOffset    float64            `json:"climb_offset,omitempty"`
+	HoldShortPoint *RoutePoint        `json:"hold_short_point,omitempty"`
+
+	// Текущие отклонения от утвержденного коридора по типам
+	Deviations map[DeviationType]bool `json:"deviations,omitempty"`
+
+	// Полет отменен пилотом, дрон возвращается в точку взлета. Заявка остается
+	// executing до посадки, CancelReason попадет в нее вместе со статусом cancelled.
+	ReturningHome bool   `json:"returning_home,omitempty"`
+	CancelReason  string `json:"cancel_reason,omitempty"`
+
+	// Крейсерская скорость после change_speed, 0 — скорость из конфигурации
+	SpeedMS float64 `json:"speed_ms,omitempty"`
+
+	// Очередь действий точки ActionWaypoint; пока дрон не покинул эту точку,
+	// к следующей он не летит. ActionElapsed — секунды, отработанные первым
+	// действием очереди: считаются по тактам, поэтому пауза их не съедает
+	Actions        []WaypointAction `json:"actions,omitempty"`
+	ActionWaypoint int              `json:"action_waypoint,omitempty"`
+	ActionRunning  bool             `json:"action_running,omitempty"`
+	ActionElapsed  float64          `json:"action_elapsed,omitempty"`
+	ActionHeading  float64          `json:"action_heading,omitempty"`
+
+	// Точка отсчета графика для контроля времени: после действий в точке
+	// график начинается заново с пройденной к этому моменту дистанции
+	ScheduleStart time.Time `json:"schedule_start,omitempty"`
+	ScheduleFlown float64   `json:"schedule_flown,omitempty"`
 }
 type RoutePoint struct {
-	Id            int     `json:"route_id"`
-	Latitude      float64 `json:"latitude"`
-	Longitude     float64 `json:"longitude"`
-	Altitude      float64 `json:"altitude"`
-	PointOrder    int     `json:"point_order"`
-	ApplicationId int     `json:"application_id"`
+	Id            int              `json:"route_id"`
+	Latitude      float64          `json:"latitude"`
+	Longitude     float64          `json:"longitude"`
+	Altitude      float64          `json:"altitude"`
+	PointOrder    int              `json:"point_order"`
+	ApplicationId int              `json:"application_id"`
+	Actions       []WaypointAction `json:"actions,omitempty"`
+}
+
+type WaypointActionType string
+
+const (
+	ActionHover       WaypointActionType = "hover"
+	ActionOrbit       WaypointActionType = "orbit"
+	ActionPhoto       WaypointActionType = "photo"
+	ActionDropPayload WaypointActionType = "drop_payload"
+	ActionChangeSpeed WaypointActionType = "change_speed"
+)
+
+// WaypointAction — действие, которое дрон выполняет по прибытии в точку.
+// Seconds задает длительность hover, Radius и Turns — круг orbit, Speed —
+// новую крейсерскую скорость для change_speed.
+type WaypointAction struct {
+	Type    WaypointActionType `json:"type"`
+	Seconds float64            `json:"seconds,omitempty"`
+	Radius  float64            `json:"radius,omitempty"`
+	Turns   float64            `json:"turns,omitempty"`
+	Speed   float64            `json:"speed,omitempty"`
+}
+
+type ConflictResolution string
+
+const (
+	ResolutionNone      ConflictResolution = "none"
+	ResolutionHold      ConflictResolution = "hold"
+	ResolutionClimb     ConflictResolution = "climb"
+	ResolutionHoldShort ConflictResolution = "hold_short"
+)
+
+type TrafficConflict struct {
+	ApplicationId         int                `json:"application_id"`
+	IntruderApplicationId int                `json:"intruder_application_id"`
+	HorizontalSeparation  float64            `json:"horizontal_separation"`
+	VerticalSeparation    float64            `json:"vertical_separation"`
+	Resolution            ConflictResolution `json:"resolution"`
+	DetectedAt            time.Time          `json:"detected_at"`
+}
+
+type DeviationType string
+
+const (
+	DeviationLateral  DeviationType = "lateral"
+	DeviationVertical DeviationType = "vertical"
+	DeviationTime     DeviationType = "time"
+)
+
+type ConformanceReport struct {
+	Leg                  int     `json:"leg"`
+	LateralDeviation     float64 `json:"lateral_deviation"`
+	VerticalDeviation    float64 `json:"vertical_deviation"`
+	TimeDeviationSeconds float64 `json:"time_deviation_seconds"`
+}
+
+// FlightEvent — запись истории полета. Details хранит поля события в JSON
+// для воспроизведения: по Message их восстанавливать нельзя.
+type FlightEvent struct {
+	Id            int64           `json:"event_id"`
+	ApplicationId int             `json:"application_id"`
+	DroneId       int             `json:"drone_id"`
+	EventType     string          `json:"event_type"`
+	AlertLevel    string          `json:"alert_level,omitempty"`
+	Message       string          `json:"message"`
+	Details       json.RawMessage `json:"details,omitempty"`
+	Latitude      float64         `json:"latitude"`
+	Longitude     float64         `json:"longitude"`
+	Altitude      float64         `json:"altitude"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// WaypointActionDetails — Details события waypoint_action.
+type WaypointActionDetails struct {
+	Waypoint int            `json:"waypoint"`
+	Action   WaypointAction `json:"action"`
+	Phase    string         `json:"phase"`
 }
 
 type Notification struct {
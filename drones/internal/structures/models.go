@@ -1,7 +1,7 @@
 package structures
 
 type Models struct {
-	Id         int    `json:"models_id, omitemtpy"`
+	Id         int    `json:"models_id,omitempty"`
 	Model_name string `json:"model_name"`
 	Brand_id   int    `json:"brand_id"`
 }
@@ -0,0 +1,19 @@
+package structures
+
+// Типы событий, которые доставляются через outbox.
+const (
+	OutboxStatusUpdate    = "status_update"
+	OutboxFlightStarted   = "flight_started"
+	OutboxFlightCompleted = "flight_completed"
+)
+
+// OutboxMessage - уведомление для бэкенда, сохраненное до отправки.
+// Payload хранит запрос gRPC в бинарном protobuf.
+type OutboxMessage struct {
+	Id             int64
+	IdempotencyKey string
+	ApplicationId  int
+	EventType      string
+	Payload        []byte
+	Attempts       int
+}
@@ -26,11 +26,19 @@ const (
 	FlightNotificationService_NotifyRestrictedZoneProximity_FullMethodName = "/flight.FlightNotificationService/NotifyRestrictedZoneProximity"
 	FlightNotificationService_NotifyFlightPaused_FullMethodName            = "/flight.FlightNotificationService/NotifyFlightPaused"
 	FlightNotificationService_NotifyFlightResumed_FullMethodName           = "/flight.FlightNotificationService/NotifyFlightResumed"
+	FlightNotificationService_NotifyTrafficConflict_FullMethodName         = "/flight.FlightNotificationService/NotifyTrafficConflict"
+	FlightNotificationService_NotifyRouteDeviation_FullMethodName          = "/flight.FlightNotificationService/NotifyRouteDeviation"
+	FlightNotificationService_NotifyWaypointAction_FullMethodName          = "/flight.FlightNotificationService/NotifyWaypointAction"
+	FlightNotificationService_StreamTelemetry_FullMethodName               = "/flight.FlightNotificationService/StreamTelemetry"
 )
 
 // FlightNotificationServiceClient is the client API for FlightNotificationService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Каждый запрос уведомления несет event_id. Повторная отправка того же
+// события приходит с тем же event_id, и сервер отвечает прежним результатом,
+// не рассылая событие второй раз.
 type FlightNotificationServiceClient interface {
 	NotifyStatusUpdate(ctx context.Context, in *StatusUpdateRequest, opts ...grpc.CallOption) (*StatusUpdateResponse, error)
 	NotifyFlightStarted(ctx context.Context, in *FlightStartedRequest, opts ...grpc.CallOption) (*FlightStartedResponse, error)
@@ -39,6 +47,10 @@ type FlightNotificationServiceClient interface {
 	NotifyRestrictedZoneProximity(ctx context.Context, in *RestrictedZoneAlertRequest, opts ...grpc.CallOption) (*RestrictedZoneAlertResponse, error)
 	NotifyFlightPaused(ctx context.Context, in *FlightPausedRequest, opts ...grpc.CallOption) (*FlightPausedResponse, error)
 	NotifyFlightResumed(ctx context.Context, in *FlightResumedRequest, opts ...grpc.CallOption) (*FlightResumedResponse, error)
+	NotifyTrafficConflict(ctx context.Context, in *TrafficConflictRequest, opts ...grpc.CallOption) (*TrafficConflictResponse, error)
+	NotifyRouteDeviation(ctx context.Context, in *RouteDeviationRequest, opts ...grpc.CallOption) (*RouteDeviationResponse, error)
+	NotifyWaypointAction(ctx context.Context, in *WaypointActionRequest, opts ...grpc.CallOption) (*WaypointActionResponse, error)
+	StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TelemetryBatch, TelemetryAck], error)
 }
 
 type flightNotificationServiceClient struct {
@@ -119,9 +131,56 @@ func (c *flightNotificationServiceClient) NotifyFlightResumed(ctx context.Contex
 	return out, nil
 }
 
+func (c *flightNotificationServiceClient) NotifyTrafficConflict(ctx context.Context, in *TrafficConflictRequest, opts ...grpc.CallOption) (*TrafficConflictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrafficConflictResponse)
+	err := c.cc.Invoke(ctx, FlightNotificationService_NotifyTrafficConflict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightNotificationServiceClient) NotifyRouteDeviation(ctx context.Context, in *RouteDeviationRequest, opts ...grpc.CallOption) (*RouteDeviationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteDeviationResponse)
+	err := c.cc.Invoke(ctx, FlightNotificationService_NotifyRouteDeviation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightNotificationServiceClient) NotifyWaypointAction(ctx context.Context, in *WaypointActionRequest, opts ...grpc.CallOption) (*WaypointActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaypointActionResponse)
+	err := c.cc.Invoke(ctx, FlightNotificationService_NotifyWaypointAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightNotificationServiceClient) StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TelemetryBatch, TelemetryAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FlightNotificationService_ServiceDesc.Streams[0], FlightNotificationService_StreamTelemetry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TelemetryBatch, TelemetryAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightNotificationService_StreamTelemetryClient = grpc.BidiStreamingClient[TelemetryBatch, TelemetryAck]
+
 // FlightNotificationServiceServer is the server API for FlightNotificationService service.
 // All implementations must embed UnimplementedFlightNotificationServiceServer
 // for forward compatibility.
+//
+// Каждый запрос уведомления несет event_id. Повторная отправка того же
+// события приходит с тем же event_id, и сервер отвечает прежним результатом,
+// не рассылая событие второй раз.
 type FlightNotificationServiceServer interface {
 	NotifyStatusUpdate(context.Context, *StatusUpdateRequest) (*StatusUpdateResponse, error)
 	NotifyFlightStarted(context.Context, *FlightStartedRequest) (*FlightStartedResponse, error)
@@ -130,6 +189,10 @@ type FlightNotificationServiceServer interface {
 	NotifyRestrictedZoneProximity(context.Context, *RestrictedZoneAlertRequest) (*RestrictedZoneAlertResponse, error)
 	NotifyFlightPaused(context.Context, *FlightPausedRequest) (*FlightPausedResponse, error)
 	NotifyFlightResumed(context.Context, *FlightResumedRequest) (*FlightResumedResponse, error)
+	NotifyTrafficConflict(context.Context, *TrafficConflictRequest) (*TrafficConflictResponse, error)
+	NotifyRouteDeviation(context.Context, *RouteDeviationRequest) (*RouteDeviationResponse, error)
+	NotifyWaypointAction(context.Context, *WaypointActionRequest) (*WaypointActionResponse, error)
+	StreamTelemetry(grpc.BidiStreamingServer[TelemetryBatch, TelemetryAck]) error
 	mustEmbedUnimplementedFlightNotificationServiceServer()
 }
 
@@ -161,6 +224,18 @@ func (UnimplementedFlightNotificationServiceServer) NotifyFlightPaused(context.C
 func (UnimplementedFlightNotificationServiceServer) NotifyFlightResumed(context.Context, *FlightResumedRequest) (*FlightResumedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NotifyFlightResumed not implemented")
 }
+func (UnimplementedFlightNotificationServiceServer) NotifyTrafficConflict(context.Context, *TrafficConflictRequest) (*TrafficConflictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyTrafficConflict not implemented")
+}
+func (UnimplementedFlightNotificationServiceServer) NotifyRouteDeviation(context.Context, *RouteDeviationRequest) (*RouteDeviationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyRouteDeviation not implemented")
+}
+func (UnimplementedFlightNotificationServiceServer) NotifyWaypointAction(context.Context, *WaypointActionRequest) (*WaypointActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyWaypointAction not implemented")
+}
+func (UnimplementedFlightNotificationServiceServer) StreamTelemetry(grpc.BidiStreamingServer[TelemetryBatch, TelemetryAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTelemetry not implemented")
+}
 func (UnimplementedFlightNotificationServiceServer) mustEmbedUnimplementedFlightNotificationServiceServer() {
 }
 func (UnimplementedFlightNotificationServiceServer) testEmbeddedByValue() {}
@@ -309,6 +384,67 @@ func _FlightNotificationService_NotifyFlightResumed_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlightNotificationService_NotifyTrafficConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrafficConflictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightNotificationServiceServer).NotifyTrafficConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightNotificationService_NotifyTrafficConflict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightNotificationServiceServer).NotifyTrafficConflict(ctx, req.(*TrafficConflictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightNotificationService_NotifyRouteDeviation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteDeviationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightNotificationServiceServer).NotifyRouteDeviation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightNotificationService_NotifyRouteDeviation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightNotificationServiceServer).NotifyRouteDeviation(ctx, req.(*RouteDeviationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightNotificationService_NotifyWaypointAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaypointActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightNotificationServiceServer).NotifyWaypointAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightNotificationService_NotifyWaypointAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightNotificationServiceServer).NotifyWaypointAction(ctx, req.(*WaypointActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightNotificationService_StreamTelemetry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FlightNotificationServiceServer).StreamTelemetry(&grpc.GenericServerStream[TelemetryBatch, TelemetryAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightNotificationService_StreamTelemetryServer = grpc.BidiStreamingServer[TelemetryBatch, TelemetryAck]
+
 // FlightNotificationService_ServiceDesc is the grpc.ServiceDesc for FlightNotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -344,7 +480,26 @@ var FlightNotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NotifyFlightResumed",
 			Handler:    _FlightNotificationService_NotifyFlightResumed_Handler,
 		},
+		{
+			MethodName: "NotifyTrafficConflict",
+			Handler:    _FlightNotificationService_NotifyTrafficConflict_Handler,
+		},
+		{
+			MethodName: "NotifyRouteDeviation",
+			Handler:    _FlightNotificationService_NotifyRouteDeviation_Handler,
+		},
+		{
+			MethodName: "NotifyWaypointAction",
+			Handler:    _FlightNotificationService_NotifyWaypointAction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTelemetry",
+			Handler:       _FlightNotificationService_StreamTelemetry_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/fly_service.proto",
 }
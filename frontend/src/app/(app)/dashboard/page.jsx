@@ -40,7 +40,7 @@
                     console.log(response.data.pilot.firstname + " " + response.data.pilot.lastname)
                     setName(response.data.pilot.firstname + " " + response.data.pilot.lastname )
                 } catch (err) {
-                    setError(err.response?.data?.message || "Произошла ошибка при получении пользователя")
+                    setError(err.response?.data?.error?.message || "Произошла ошибка при получении пользователя")
                     console.log(error)
                 }
             }
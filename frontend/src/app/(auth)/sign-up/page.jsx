@@ -71,7 +71,7 @@ export default function SignUp() {
         setError("Ошибка регистрации. Попробуйте снова.");
       }
     } catch (err) {
-      setError(err.response?.data?.message || "Неизвестная ошибка.");
+      setError(err.response?.data?.error?.message || "Неизвестная ошибка.");
     }
   };
 
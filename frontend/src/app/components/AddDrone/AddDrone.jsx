@@ -37,7 +37,7 @@ export default function AddDrone() {
         setBrand_name("")
       }
     } catch (err) {
-      setError(err.response?.data?.message || "Произошла ошибка при добавлении дрона")
+      setError(err.response?.data?.error?.message || "Произошла ошибка при добавлении дрона")
     } finally {
       setLoading(false)
     }
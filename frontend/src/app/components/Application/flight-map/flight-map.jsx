@@ -72,7 +72,7 @@ export default function FlightMap({ selectedPosition, onPositionSelect, dangerZo
           dangerZones.map((zone) => (
             <Circle
               key={zone.restrictedZone_id}
-              center={[zone.latitude, zone.longtitude]}
+              center={[zone.latitude, zone.longitude]}
               radius={zone.radius}
               pathOptions={{
                 color: "red",
@@ -123,7 +123,7 @@ export default function AddDangerZone() {
       const token = localStorage.getItem("token")
       const zoneData = {
         latitude: Number.parseFloat(selectedPosition[0].toFixed(6)),
-        longtitude: Number.parseFloat(selectedPosition[1].toFixed(6)),
+        longitude: Number.parseFloat(selectedPosition[1].toFixed(6)),
         altitude: Number.parseFloat(altitude),
         zone_name: zoneName.trim(),
         radius: Number.parseInt(radius),
@@ -139,7 +139,7 @@ export default function AddDangerZone() {
       resetForm()
       fetchDangerZones()
     } catch (err) {
-      setError(err.response?.data?.message || "Ошибка при создании зоны")
+      setError(err.response?.data?.error?.message || "Ошибка при создании зоны")
     } finally {
       setLoading(false)
     }
@@ -253,7 +253,7 @@ export default function AddDangerZone() {
                 {zones.map((zone) => (
                   <Circle
                     key={zone.restrictedZone_id}
-                    center={[zone.latitude, zone.longtitude]}
+                    center={[zone.latitude, zone.longitude]}
                     radius={zone.radius}
                     pathOptions={{
                       color: "red",
@@ -362,7 +362,7 @@ export default function AddDangerZone() {
                   <tr key={zone.restrictedZone_id} className="hover:bg-gray-50">
                     <td className="px-6 py-4 whitespace-nowrap text-sm font-medium text-gray-900">{zone.zone_name}</td>
                     <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
-                      {zone.latitude?.toFixed(5)}, {zone.longtitude?.toFixed(5)}
+                      {zone.latitude?.toFixed(5)}, {zone.longitude?.toFixed(5)}
                     </td>
                     <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">{zone.radius} м</td>
                     <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">{zone.altitude} м</td>
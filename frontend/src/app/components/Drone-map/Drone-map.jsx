@@ -181,7 +181,7 @@ export default function DroneMap({ drones, selectedDrone, dangerZones, center, o
       {dangerZones.map((zone) => (
         <Circle
           key={zone.restrictedZone_id}
-          center={[zone.latitude, zone.longtitude]}
+          center={[zone.latitude, zone.longitude]}
           radius={zone.radius}
           pathOptions={{
             color: "red",
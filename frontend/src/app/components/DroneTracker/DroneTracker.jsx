@@ -33,6 +33,7 @@ export default function DroneTracker() {
   const messageIdRef = useRef(0)
   const connectionAttemptsRef = useRef(0)
   const isConnectingRef = useRef(false)
+  const lastEventIdRef = useRef(0)
 
   const center = [51.12, 71.43]
 
@@ -59,7 +60,7 @@ export default function DroneTracker() {
       const nowTime = now()
 
       dangerZones.forEach((zone) => {
-        const distance = calculateDistance(dronePos.latitude, dronePos.longitude, zone.latitude, zone.longtitude)
+        const distance = calculateDistance(dronePos.latitude, dronePos.longitude, zone.latitude, zone.longitude)
         const warningDistance = zone.radius + 50
 
         if (distance <= warningDistance && distance > zone.radius) {
@@ -101,7 +102,9 @@ export default function DroneTracker() {
     setIsConnecting(true)
 
     try {
-      wsRef.current = new WebSocket("ws://localhost:5050/ws")
+      const token = localStorage.getItem("token") || ""
+      const resume = lastEventIdRef.current ? `&last_event_id=${lastEventIdRef.current}` : ""
+      wsRef.current = new WebSocket(`ws://localhost:5050/ws?token=${encodeURIComponent(token)}${resume}`)
 
       wsRef.current.onopen = () => {
         setIsConnected(true)
@@ -114,6 +117,9 @@ export default function DroneTracker() {
       wsRef.current.onmessage = (event) => {
         try {
           const data = JSON.parse(event.data)
+          if (data.event_id && data.event_id > lastEventIdRef.current) {
+            lastEventIdRef.current = data.event_id
+          }
           handleMessage(data)
         } catch (error) {
           console.log("Error parsing WebSocket message:", error)
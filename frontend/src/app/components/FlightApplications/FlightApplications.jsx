@@ -188,7 +188,7 @@ export default function FlightApplications() {
                       <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
                         { app.start_date.split(" ")[1]}
                       </td>
-                      <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">{app.latitude} {app.longtitude}</td>
+                      <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">{app.latitude} {app.longitude}</td>
                       <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
                         {app.altitude ? `${app.altitude} м` : app.altitude}
                       </td>
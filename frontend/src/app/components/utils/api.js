@@ -43,12 +43,8 @@ api.interceptors.response.use(
     }
 
 
-    if (
-      error.response?.data?.message?.includes("token") ||
-      error.response?.data?.message?.includes("expired") ||
-      error.response?.data?.message?.includes("unauthorized")
-    ) {
-      console.log("Проблема с токеном:", error.response.data.message)
+    if (error.response?.data?.error?.code === "unauthorized") {
+      console.log("Проблема с токеном:", error.response.data.error.message)
       redirectToAuth()
     }
 
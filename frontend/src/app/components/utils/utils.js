@@ -13,7 +13,7 @@ export const checkIntersectionWithZones = (position, dangerZones) => {
   const intersectedZones = []
 
   for (const zone of dangerZones) {
-    const distance = calculateDistance(lat, lng, zone.latitude, zone.longtitude)
+    const distance = calculateDistance(lat, lng, zone.latitude, zone.longitude)
     if (distance <= zone.radius) {
       hasIntersection = true
       intersectedZones.push(zone)
@@ -34,7 +34,7 @@ export const prepareApplicationData = (formData) => {
     status: "Pending",
     drone_id: Number(formData.selectedDrone),
     latitude: formData.selectedPosition ? Number.parseFloat(formData.selectedPosition[0].toFixed(8)) : null,
-    longtitude: formData.selectedPosition ? Number.parseFloat(formData.selectedPosition[1].toFixed(8)) : null,
+    longitude: formData.selectedPosition ? Number.parseFloat(formData.selectedPosition[1].toFixed(8)) : null,
     altitude: Number.parseInt(formData.maxHeight),
     tested: formData.tested ? 1 : 0, 
   }
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS flight_history (
+    event_id       BIGINT AUTO_INCREMENT PRIMARY KEY,
+    application_id INT NOT NULL,
+    drone_id       INT NOT NULL,
+    event_type     VARCHAR(64) NOT NULL,
+    message        VARCHAR(512) NOT NULL DEFAULT '',
+    latitude       DOUBLE NOT NULL DEFAULT 0,
+    longitude      DOUBLE NOT NULL DEFAULT 0,
+    altitude       DOUBLE NOT NULL DEFAULT 0,
+    created_at     DATETIME(3) NOT NULL,
+    INDEX idx_flight_history_application (application_id, created_at)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS drone_telemetry (
+    telemetry_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    application_id INT NOT NULL,
+    drone_id       INT NOT NULL,
+    latitude       DOUBLE NOT NULL,
+    longitude      DOUBLE NOT NULL,
+    altitude       DOUBLE NOT NULL,
+    speed          DOUBLE NOT NULL DEFAULT 0,
+    heading        DOUBLE NOT NULL DEFAULT 0,
+    route_progress DOUBLE NOT NULL DEFAULT 0,
+    timestamp      DATETIME(3) NOT NULL,
+    INDEX idx_drone_telemetry_application_time (application_id, timestamp)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE flight_history
+    ADD COLUMN alert_level VARCHAR(16) NOT NULL DEFAULT '' AFTER event_type;
@@ -0,0 +1,2 @@
+ALTER TABLE Pilot
+    ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'pilot';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notification_events (
+    event_id       BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_type     VARCHAR(64) NOT NULL,
+    application_id INT NOT NULL,
+    drone_id       INT NOT NULL DEFAULT 0,
+    pilot_id       INT NOT NULL DEFAULT 0,
+    latitude       DOUBLE NULL,
+    longitude      DOUBLE NULL,
+    payload        JSON NOT NULL,
+    created_at     DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_notification_events_created_at (created_at)
+);
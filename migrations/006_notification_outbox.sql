@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    outbox_id       BIGINT AUTO_INCREMENT PRIMARY KEY,
+    idempotency_key VARCHAR(64) NOT NULL,
+    application_id  INT NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    payload         MEDIUMBLOB NOT NULL,
+    status          ENUM('pending', 'delivered', 'failed') NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    last_error      TEXT NULL,
+    created_at      DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    delivered_at    DATETIME(6) NULL,
+    UNIQUE KEY uq_notification_outbox_key (idempotency_key),
+    INDEX idx_notification_outbox_due (status, next_attempt_at),
+    INDEX idx_notification_outbox_application (application_id, status, outbox_id)
+);
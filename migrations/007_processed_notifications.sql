@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS processed_notifications (
+    event_id       VARCHAR(64) PRIMARY KEY,
+    event_type     VARCHAR(64) NOT NULL,
+    application_id INT NOT NULL,
+    created_at     DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_processed_notifications_created_at (created_at)
+);
@@ -0,0 +1,9 @@
+ALTER TABLE Application
+    ADD INDEX idx_application_pilot_start (pilot_id, start_date, application_id),
+    ADD INDEX idx_application_pilot_status (pilot_id, status, application_id);
+
+ALTER TABLE Route
+    ADD INDEX idx_route_application_order (application_id, point_order, route_id);
+
+ALTER TABLE Restricted_zones
+    ADD INDEX idx_restricted_zones_position (latitude, longtitude);
@@ -0,0 +1,17 @@
+ALTER TABLE Application
+    ADD COLUMN revision INT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS application_revisions (
+    revision_id      BIGINT AUTO_INCREMENT PRIMARY KEY,
+    application_id   INT NOT NULL,
+    revision         INT NOT NULL,
+    start_date       VARCHAR(32) NOT NULL,
+    end_date         VARCHAR(32) NOT NULL,
+    drone_id         INT NOT NULL,
+    status           VARCHAR(32) NOT NULL,
+    rejection_reason TEXT NULL,
+    route            JSON NOT NULL,
+    amended_by       INT NOT NULL,
+    created_at       DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY uq_application_revisions (application_id, revision)
+);
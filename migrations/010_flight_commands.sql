@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS flight_commands (
+    command_id     BIGINT AUTO_INCREMENT PRIMARY KEY,
+    application_id INT NOT NULL,
+    command        ENUM('abort', 'return_home') NOT NULL,
+    reason         TEXT NOT NULL,
+    requested_by   INT NOT NULL,
+    created_at     DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    processed_at   DATETIME(6) NULL,
+    INDEX idx_flight_commands_pending (processed_at, command_id),
+    INDEX idx_flight_commands_application (application_id, command_id)
+);
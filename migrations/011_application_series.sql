@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS application_series (
+    series_id     INT AUTO_INCREMENT PRIMARY KEY,
+    pilot_id      INT NOT NULL,
+    drone_id      INT NOT NULL,
+    rrule         VARCHAR(512) NOT NULL,
+    status        ENUM('active', 'cancelled') NOT NULL DEFAULT 'active',
+    cancel_reason TEXT NULL,
+    created_at    DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_application_series_pilot (pilot_id, series_id)
+);
+
+ALTER TABLE Application
+    ADD COLUMN series_id INT NULL,
+    ADD INDEX idx_application_series (series_id, start_date);
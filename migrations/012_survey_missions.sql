@@ -0,0 +1,11 @@
+ALTER TABLE Application
+    ADD COLUMN mission_type ENUM('point', 'survey') NOT NULL DEFAULT 'point';
+
+CREATE TABLE IF NOT EXISTS survey_missions (
+    application_id  INT PRIMARY KEY,
+    polygon         JSON NOT NULL,
+    altitude        DOUBLE NOT NULL,
+    footprint_width DOUBLE NOT NULL,
+    overlap         DOUBLE NOT NULL,
+    created_at      DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE Route
+    ADD COLUMN actions JSON NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE flight_history
+    ADD COLUMN details JSON NULL AFTER message;